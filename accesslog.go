@@ -0,0 +1,274 @@
+package randomdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AccessLogFormat is the layout of a web server access log line.
+type AccessLogFormat int
+
+const (
+	// ApacheCommonLog is the Common Log Format of Apache httpd.
+	ApacheCommonLog AccessLogFormat = iota
+	// ApacheCombinedLog is the Common Log Format followed by the referer and the user agent.
+	ApacheCombinedLog
+	// NginxLog is the default "main" format of nginx: the combined format followed by X-Forwarded-For.
+	NginxLog
+	// ELBLog is the access log format of AWS Classic Load Balancers.
+	ELBLog
+	// W3CLog is the W3C extended log format, with the fields logged by default by IIS.
+	W3CLog
+	// JSONLog is one JSON object per line.
+	JSONLog
+)
+
+// String returns the name of the access log format.
+func (f AccessLogFormat) String() string {
+	switch f {
+	case ApacheCommonLog:
+		return "Apache common"
+	case ApacheCombinedLog:
+		return "Apache combined"
+	case NginxLog:
+		return "nginx"
+	case ELBLog:
+		return "ELB"
+	case W3CLog:
+		return "W3C extended"
+	case JSONLog:
+		return "JSON"
+	}
+	return "unknown"
+}
+
+// AccessLogEntry is a request served by a web server, as recorded in its access log.
+type AccessLogEntry struct {
+	Time          time.Time     `json:"time"`
+	RemoteAddr    string        `json:"remoteAddr"`
+	RemotePort    int           `json:"remotePort"`
+	User          string        `json:"user"` // empty when the request is not authenticated
+	Method        string        `json:"method"`
+	URI           string        `json:"uri"` // path and query
+	Proto         string        `json:"proto"`
+	Host          string        `json:"host"`
+	Status        int           `json:"status"`
+	Bytes         int64         `json:"bytes"`         // size of the response body
+	ReceivedBytes int64         `json:"receivedBytes"` // size of the request body
+	Referer       string        `json:"referer"`
+	UserAgent     string        `json:"userAgent"`
+	Duration      time.Duration `json:"duration"`
+	ForwardedFor  string        `json:"forwardedFor"` // empty when the request did not go through a proxy
+	ServerAddr    string        `json:"serverAddr"`
+	ServerPort    int           `json:"serverPort"`
+}
+
+// AccessLogEntry returns a random request served at the supplied time.
+// The client address comes from IpV4Address and its agent from UserAgentString.
+func (r *Rand) AccessLogEntry(at time.Time) AccessLogEntry {
+	method := r.httpMethod()
+	queryParams := 0
+	if method == http.MethodGet && r.Intn(3) == 0 {
+		queryParams = r.Number(1, 3)
+	}
+	host := r.DomainName()
+	u := r.URL(URLOptions{Host: host, PathSegments: r.Intn(4), QueryParams: queryParams})
+	entry := AccessLogEntry{
+		Time:       at,
+		RemoteAddr: r.IpV4Address(),
+		RemotePort: r.Number(1024, 65536),
+		Method:     method,
+		URI:        u.RequestURI(),
+		Proto:      []string{"HTTP/1.1", "HTTP/1.1", "HTTP/2.0"}[r.Intn(3)],
+		Host:       host,
+		Status:     r.HTTPStatusCode(method),
+		UserAgent:  r.UserAgentString(),
+		ServerAddr: r.IPv4(PrivateAddress).String(),
+		ServerPort: []int{80, 443, 443, 8080}[r.Intn(4)],
+	}
+	if r.Intn(10) == 0 {
+		entry.User = emailAtom(r.FirstName(RandomGender))
+	}
+	switch {
+	case entry.Status == http.StatusNoContent || entry.Status == http.StatusNotModified || method == http.MethodHead:
+	case entry.Status >= 300:
+		// Redirections and errors come with short pages.
+		entry.Bytes = int64(math.Exp(6 + 0.5*r.NormFloat64()))
+	default:
+		// Response sizes follow a log-normal distribution with a median around 3 KB.
+		entry.Bytes = int64(math.Exp(8 + 1.5*r.NormFloat64()))
+	}
+	if method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch {
+		entry.ReceivedBytes = int64(math.Exp(6 + r.NormFloat64()))
+	}
+	switch r.Intn(5) {
+	case 0, 1:
+		entry.Referer = "https://" + entry.Host + "/" + domainLabel(r.Noun())
+	case 2:
+		entry.Referer = "https://www.google.com/"
+	}
+	if r.Intn(4) == 0 {
+		entry.ForwardedFor = r.IpV4Address()
+	}
+	// Durations follow a log-normal distribution with a median around 50 ms.
+	entry.Duration = time.Duration(math.Exp(-3+r.NormFloat64()) * float64(time.Second)).Round(time.Microsecond)
+	return entry
+}
+
+// AccessLogLine returns a random access log line of the supplied format, for a request served now.
+func (r *Rand) AccessLogLine(format AccessLogFormat) string {
+	return r.AccessLogEntry(time.Now()).Format(format)
+}
+
+// Format returns the entry as a line of the supplied access log format, without the trailing newline.
+func (e AccessLogEntry) Format(format AccessLogFormat) string {
+	dash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	request := e.Method + " " + e.URI + " " + e.Proto
+	clf := fmt.Sprintf("%s - %s [%s] %q %d", e.RemoteAddr, dash(e.User), e.Time.Format("02/Jan/2006:15:04:05 -0700"), request, e.Status)
+	switch format {
+	case ApacheCommonLog, ApacheCombinedLog:
+		size := "-"
+		if e.Bytes > 0 {
+			size = strconv.FormatInt(e.Bytes, 10)
+		}
+		if format == ApacheCommonLog {
+			return clf + " " + size
+		}
+		return fmt.Sprintf("%s %s %q %q", clf, size, dash(e.Referer), dash(e.UserAgent))
+	case NginxLog:
+		return fmt.Sprintf("%s %d %q %q %q", clf, e.Bytes, dash(e.Referer), dash(e.UserAgent), dash(e.ForwardedFor))
+	case ELBLog:
+		scheme, cipher, protocol := "http", "-", "-"
+		if e.ServerPort == 443 {
+			scheme, cipher, protocol = "https", "ECDHE-RSA-AES128-GCM-SHA256", "TLSv1.2"
+		}
+		// The time spent by the load balancer itself is negligible, the backend accounts for the request time.
+		return fmt.Sprintf("%s %s %s:%d %s:%d 0.000045 %.6f 0.000038 %d %d %d %d \"%s %s://%s:%d%s %s\" %q %s %s",
+			e.Time.UTC().Format("2006-01-02T15:04:05.000000Z"), elbName(e.Host), e.RemoteAddr, e.RemotePort,
+			e.ServerAddr, e.ServerPort, e.Duration.Seconds(), e.Status, e.Status,
+			e.ReceivedBytes, e.Bytes, e.Method, scheme, e.Host, e.ServerPort, e.URI, e.Proto, dash(e.UserAgent), cipher, protocol)
+	case W3CLog:
+		stem, query := e.URI, ""
+		if i := strings.Index(e.URI, "?"); i >= 0 {
+			stem, query = e.URI[:i], e.URI[i+1:]
+		}
+		w3c := func(s string) string {
+			return dash(strings.Replace(s, " ", "+", -1))
+		}
+		return fmt.Sprintf("%s %s %s %s %s %d %s %s %s %s %d 0 0 %d",
+			e.Time.UTC().Format("2006-01-02 15:04:05"), e.ServerAddr, e.Method, stem, dash(query), e.ServerPort,
+			dash(e.User), e.RemoteAddr, w3c(e.UserAgent), w3c(e.Referer), e.Status, e.Duration.Milliseconds())
+	case JSONLog:
+		var line strings.Builder
+		encoder := json.NewEncoder(&line)
+		encoder.SetEscapeHTML(false)
+		encoder.Encode(struct {
+			Time          string  `json:"time"`
+			RemoteAddr    string  `json:"remote_addr"`
+			RemoteUser    string  `json:"remote_user,omitempty"`
+			Host          string  `json:"host"`
+			Method        string  `json:"method"`
+			URI           string  `json:"uri"`
+			Protocol      string  `json:"protocol"`
+			Status        int     `json:"status"`
+			BytesSent     int64   `json:"bytes_sent"`
+			BytesReceived int64   `json:"bytes_received"`
+			Referer       string  `json:"referer,omitempty"`
+			UserAgent     string  `json:"user_agent"`
+			ForwardedFor  string  `json:"x_forwarded_for,omitempty"`
+			RequestTime   float64 `json:"request_time"`
+		}{
+			e.Time.Format("2006-01-02T15:04:05.000000Z07:00"), e.RemoteAddr, e.User, e.Host, e.Method, e.URI, e.Proto, e.Status,
+			e.Bytes, e.ReceivedBytes, e.Referer, e.UserAgent, e.ForwardedFor, e.Duration.Seconds(),
+		})
+		return strings.TrimSuffix(line.String(), "\n")
+	}
+	return ""
+}
+
+// elbName returns the name of the load balancer serving a host.
+func elbName(host string) string {
+	return strings.SplitN(host, ".", 2)[0] + "-lb"
+}
+
+// w3cHeader returns the directives starting a W3C extended log file.
+func w3cHeader(at time.Time) string {
+	return "#Software: Microsoft Internet Information Services 10.0\n" +
+		"#Version: 1.0\n" +
+		"#Date: " + at.UTC().Format("2006-01-02 15:04:05") + "\n" +
+		"#Fields: date time s-ip cs-method cs-uri-stem cs-uri-query s-port cs-username c-ip cs(User-Agent) cs(Referer) " +
+		"sc-status sc-substatus sc-win32-status time-taken\n"
+}
+
+// AccessLogOptions drives WriteAccessLog.
+type AccessLogOptions struct {
+	// Format is the layout of the lines.
+	Format AccessLogFormat
+	// Start is the time of the first simulated second. The zero value means time.Now().
+	Start time.Time
+	// PerSecond is the number of lines of each simulated second. The zero value means 10.
+	PerSecond int
+	// Seconds is the number of simulated seconds. The zero value means 60.
+	Seconds int
+}
+
+// WriteAccessLog writes an access log to w, one line per request, without waiting for the simulated time to pass.
+// Each simulated second holds PerSecond requests whose timestamps are spread within the second, in order.
+// W3C logs start with their directives. It stops at the first error returned by w.
+func (r *Rand) WriteAccessLog(w io.Writer, opts AccessLogOptions) error {
+	start, perSecond, seconds := simulatedClock(opts.Start, opts.PerSecond, opts.Seconds)
+	if opts.Format == W3CLog {
+		if _, err := io.WriteString(w, w3cHeader(start)); err != nil {
+			return err
+		}
+	}
+	return r.simulatedOffsets(perSecond, seconds, func(elapsed time.Duration) error {
+		_, err := io.WriteString(w, r.AccessLogEntry(start.Add(elapsed)).Format(opts.Format)+"\n")
+		return err
+	})
+}
+
+// simulatedClock applies the defaults of the simulated clocks of the log writers: a start of time.Now(),
+// 10 events per second and 60 seconds.
+func simulatedClock(start time.Time, perSecond, seconds int) (time.Time, int, int) {
+	if start.IsZero() {
+		start = time.Now()
+	}
+	if perSecond <= 0 {
+		perSecond = 10
+	}
+	if seconds <= 0 {
+		seconds = 60
+	}
+	return start, perSecond, seconds
+}
+
+// simulatedOffsets calls fn in order with the offset from the start of each event of a simulated clock,
+// the perSecond events of each second being spread at random within it. It stops at the first error returned by fn.
+func (r *Rand) simulatedOffsets(perSecond, seconds int, fn func(elapsed time.Duration) error) error {
+	offsets := make([]time.Duration, perSecond)
+	for s := 0; s < seconds; s++ {
+		for i := range offsets {
+			offsets[i] = time.Duration(r.Intn(int(time.Second/time.Microsecond))) * time.Microsecond
+		}
+		sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+		for _, offset := range offsets {
+			if err := fn(time.Duration(s)*time.Second + offset); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
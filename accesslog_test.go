@@ -0,0 +1,107 @@
+package randomdata
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var accessLogPatterns = map[AccessLogFormat]*regexp.Regexp{
+	ApacheCommonLog:   regexp.MustCompile(`^[\d.]+ - (-|\S+) \[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\] "[A-Z]+ /\S* HTTP/[\d.]+" \d{3} (-|\d+)$`),
+	ApacheCombinedLog: regexp.MustCompile(`^[\d.]+ - (-|\S+) \[[^\]]+\] "[A-Z]+ /\S* HTTP/[\d.]+" \d{3} (-|\d+) "[^"]+" "[^"]+"$`),
+	NginxLog:          regexp.MustCompile(`^[\d.]+ - (-|\S+) \[[^\]]+\] "[A-Z]+ /\S* HTTP/[\d.]+" \d{3} \d+ "[^"]+" "[^"]+" "(-|[\d.]+)"$`),
+	ELBLog: regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{6}Z \S+ [\d.]+:\d+ [\d.]+:\d+ [\d.]+ [\d.]+ [\d.]+ \d{3} \d{3} \d+ \d+ ` +
+		`"[A-Z]+ https?://\S+:\d+/\S* HTTP/[\d.]+" "[^"]+" (-|\S+) (-|TLSv[\d.]+)$`),
+	W3CLog: regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2} [\d.]+ [A-Z]+ /\S* \S+ \d+ \S+ [\d.]+ \S+ \S+ \d{3} 0 0 \d+$`),
+}
+
+func TestAccessLogLine(t *testing.T) {
+	r := FromSeed(1234)
+	for format, pattern := range accessLogPatterns {
+		for i := 0; i < 200; i++ {
+			line := r.AccessLogLine(format)
+			assert.Regexp(t, pattern, line, "invalid %v line", format)
+		}
+	}
+	for i := 0; i < 200; i++ {
+		line := r.AccessLogLine(JSONLog)
+		var object map[string]interface{}
+		if assert.NoError(t, json.Unmarshal([]byte(line), &object), line) {
+			assert.NotEmpty(t, object["remote_addr"])
+			assert.NotZero(t, object["status"])
+			_, err := time.Parse(time.RFC3339Nano, object["time"].(string))
+			assert.NoError(t, err)
+		}
+		assert.NotContains(t, line, "\n")
+	}
+}
+
+func TestAccessLogEntryFormat(t *testing.T) {
+	entry := AccessLogEntry{
+		Time:       time.Date(2000, 10, 10, 13, 55, 36, 0, time.FixedZone("", -7*3600)),
+		RemoteAddr: "127.0.0.1", RemotePort: 52000, User: "frank",
+		Method: "GET", URI: "/apache_pb.gif?x=1", Proto: "HTTP/1.0", Host: "www.example.com",
+		Status: 200, Bytes: 2326, Referer: "http://www.example.com/start.html", UserAgent: "Mozilla/4.08 [en] (Win98; I ;Nav)",
+		Duration: 15 * time.Millisecond, ServerAddr: "10.0.0.1", ServerPort: 443,
+	}
+	assert.Equal(t, `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif?x=1 HTTP/1.0" 200 2326`, entry.Format(ApacheCommonLog))
+	assert.Equal(t, `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif?x=1 HTTP/1.0" 200 2326 `+
+		`"http://www.example.com/start.html" "Mozilla/4.08 [en] (Win98; I ;Nav)"`, entry.Format(ApacheCombinedLog))
+	assert.Equal(t, `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif?x=1 HTTP/1.0" 200 2326 `+
+		`"http://www.example.com/start.html" "Mozilla/4.08 [en] (Win98; I ;Nav)" "-"`, entry.Format(NginxLog))
+	assert.Equal(t, `2000-10-10T20:55:36.000000Z www-lb 127.0.0.1:52000 10.0.0.1:443 0.000045 0.015000 0.000038 200 200 0 2326 `+
+		`"GET https://www.example.com:443/apache_pb.gif?x=1 HTTP/1.0" "Mozilla/4.08 [en] (Win98; I ;Nav)" ECDHE-RSA-AES128-GCM-SHA256 TLSv1.2`,
+		entry.Format(ELBLog))
+	assert.Equal(t, `2000-10-10 20:55:36 10.0.0.1 GET /apache_pb.gif x=1 443 frank 127.0.0.1 Mozilla/4.08+[en]+(Win98;+I+;Nav) `+
+		`http://www.example.com/start.html 200 0 0 15`, entry.Format(W3CLog))
+
+	entry.Bytes, entry.User = 0, ""
+	assert.Equal(t, `127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif?x=1 HTTP/1.0" 200 -`, entry.Format(ApacheCommonLog))
+}
+
+func TestWriteAccessLog(t *testing.T) {
+	r := FromSeed(1234)
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	assert.NoError(t, r.WriteAccessLog(&buf, AccessLogOptions{Format: JSONLog, Start: start, PerSecond: 25, Seconds: 4}))
+
+	scanner := bufio.NewScanner(&buf)
+	var last time.Time
+	perSecond := map[int]int{}
+	for scanner.Scan() {
+		var object struct {
+			Time time.Time `json:"time"`
+		}
+		if !assert.NoError(t, json.Unmarshal(scanner.Bytes(), &object)) {
+			continue
+		}
+		assert.False(t, object.Time.Before(last), "%v before %v", object.Time, last)
+		assert.False(t, object.Time.Before(start))
+		last = object.Time
+		perSecond[int(object.Time.Sub(start)/time.Second)]++
+	}
+	assert.Equal(t, map[int]int{0: 25, 1: 25, 2: 25, 3: 25}, perSecond)
+
+	buf.Reset()
+	assert.NoError(t, r.WriteAccessLog(&buf, AccessLogOptions{Format: W3CLog, Start: start}))
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, 4+10*60)
+	assert.Equal(t, "#Date: 2024-03-01 12:00:00", lines[2])
+	assert.Regexp(t, accessLogPatterns[W3CLog], lines[4])
+
+	err := r.WriteAccessLog(failingWriter{}, AccessLogOptions{})
+	assert.EqualError(t, err, "disk full")
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
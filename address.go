@@ -0,0 +1,165 @@
+package randomdata
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Formats obtained from:
+// * https://github.com/google/libaddressinput (address formats and upper case fields)
+// * https://www.upu.int/en/Postal-Solutions/Programmes-Services/Addressing-Solutions (international mail)
+
+// AddressStyle decides how an Address is rendered.
+type AddressStyle int
+
+const (
+	// MultiLine renders the address on several lines, as written on a letter sent within the country.
+	MultiLine AddressStyle = iota
+	// SingleLine renders the address on one line, the lines being separated by commas.
+	SingleLine
+	// Postal renders the address as written on international mail: multi-line, with the fields
+	// the country requires in upper case, and the country name on the last line.
+	Postal
+)
+
+// Address is a postal address.
+type Address struct {
+	Unit       string `json:"unit,omitempty"` // apartment, flat or suite
+	Number     string `json:"number"`         // house number
+	Street     string `json:"street"`
+	Locality   string `json:"locality"` // city or town
+	Region     string `json:"region"`   // state, province or county as written in addresses
+	PostalCode string `json:"postalCode"`
+	Country    string `json:"country"` // 2-letter country code
+}
+
+type addressFormat struct {
+	name         string // English country name used for international mail
+	layout       string // %A street address lines, %C locality, %S region, %Z postal code, %n new line
+	upper        string // fields written in upper case on international mail
+	street       string // %b building number, %s street
+	streetUnit   string // %b building number, %s street, %u unit
+	postalPrefix string // prefix of the postal code on international mail
+}
+
+var addressFormats = map[string]addressFormat{
+	"US": {"United States", "%A%n%C, %S %Z", "CS", "%b %s", "%b %s Apt %u", ""},
+	"GB": {"United Kingdom", "%A%n%C%n%Z", "CZ", "%b %s", "Flat %u%n%b %s", ""},
+	"CA": {"Canada", "%A%n%C %S %Z", "ACSZ", "%b %s", "%u-%b %s", ""},
+	"AU": {"Australia", "%A%n%C %S %Z", "CS", "%b %s", "%u/%b %s", ""},
+	"DE": {"Germany", "%A%n%Z %C", "", "%s %b", "%s %b%nWohnung %u", ""},
+	"FR": {"France", "%A%n%Z %C", "C", "%b %s", "Appartement %u%n%b %s", ""},
+	"NL": {"Netherlands", "%A%n%Z %C", "", "%s %b", "%s %b-%u", ""},
+	"ES": {"Spain", "%A%n%Z %C %S", "CS", "%s, %b", "%s, %b, %uº", ""},
+	"IT": {"Italy", "%A%n%Z %C %S", "CS", "%s %b", "%s %b, int. %u", ""},
+	"SE": {"Sweden", "%A%n%Z %C", "", "%s %b", "%s %b, lgh %u", "SE-"},
+	"BR": {"Brazil", "%A%n%C-%S%n%Z", "CS", "%s, %b", "%s, %b, apto %u", ""},
+}
+
+// genericAddressFormat is used to render addresses of unsupported countries.
+var genericAddressFormat = addressFormat{layout: "%A%n%C %S %Z", street: "%b %s", streetUnit: "%b %s %u"}
+
+// AddressForCountry returns a random address typical to the supplied 2-letter country code.
+// If the country is not supported it will return an empty Address.
+func (r *Rand) AddressForCountry(countrycode string) Address {
+	countrycode = strings.ToUpper(countrycode)
+	if _, ok := addressFormats[countrycode]; !ok {
+		return Address{}
+	}
+	place := r.Place(countrycode)
+	address := Address{
+		Number:     r.houseNumber(),
+		Street:     r.StreetForCountry(countrycode),
+		Locality:   place.City,
+		Region:     place.Region,
+		PostalCode: place.PostalCode,
+		Country:    countrycode,
+	}
+	if r.Intn(4) == 0 {
+		address.Unit = strconv.Itoa(r.Number(1, 30))
+	}
+	return address
+}
+
+// houseNumber returns a random house number, small numbers being the most frequent.
+func (r *Rand) houseNumber() string {
+	switch n := r.Intn(10); {
+	case n < 3:
+		return strconv.Itoa(r.Number(1, 10))
+	case n < 7:
+		return strconv.Itoa(r.Number(10, 100))
+	case n < 9:
+		return strconv.Itoa(r.Number(100, 1000))
+	}
+	return strconv.Itoa(r.Number(1000, 10000))
+}
+
+// Lines returns the lines of the address as written on a letter sent within the country.
+func (a Address) Lines() []string {
+	return a.lines(false)
+}
+
+// Format renders the address following the conventions of its country.
+func (a Address) Format(style AddressStyle) string {
+	switch style {
+	case SingleLine:
+		return strings.Join(a.lines(false), ", ")
+	case Postal:
+		return strings.Join(a.lines(true), "\n")
+	}
+	return strings.Join(a.lines(false), "\n")
+}
+
+// String returns the address on a single line.
+func (a Address) String() string {
+	return a.Format(SingleLine)
+}
+
+func (a Address) lines(international bool) []string {
+	format, ok := addressFormats[a.Country]
+	if !ok {
+		format = genericAddressFormat
+		format.name = a.Country
+	}
+	street := format.street
+	if a.Unit != "" {
+		street = format.streetUnit
+	}
+	street = strings.NewReplacer("%b", a.Number, "%s", a.Street, "%u", a.Unit, "%n", "\n").Replace(street)
+
+	fields := map[byte]string{'A': street, 'C': a.Locality, 'S': a.Region, 'Z': a.PostalCode}
+	if international {
+		if format.postalPrefix != "" && a.PostalCode != "" {
+			fields['Z'] = format.postalPrefix + a.PostalCode
+		}
+		for i := 0; i < len(format.upper); i++ {
+			fields[format.upper[i]] = strings.ToUpper(fields[format.upper[i]])
+		}
+	}
+
+	var layout strings.Builder
+	for i := 0; i < len(format.layout); i++ {
+		if format.layout[i] == '%' && i+1 < len(format.layout) {
+			i++
+			if format.layout[i] == 'n' {
+				layout.WriteByte('\n')
+			} else {
+				layout.WriteString(fields[format.layout[i]])
+			}
+			continue
+		}
+		layout.WriteByte(format.layout[i])
+	}
+
+	var lines []string
+	for _, line := range strings.Split(layout.String(), "\n") {
+		line = strings.Trim(strings.Join(strings.Fields(line), " "), " ,-")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if international && format.name != "" {
+		lines = append(lines, strings.ToUpper(format.name))
+	}
+	return lines
+}
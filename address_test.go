@@ -0,0 +1,65 @@
+package randomdata
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressForCountry(t *testing.T) {
+	r := FromSeed(1234)
+	for country := range addressFormats {
+		for i := 0; i < 50; i++ {
+			a := r.AddressForCountry(strings.ToLower(country))
+			assert.Equal(t, country, a.Country)
+			assert.NotEmpty(t, a.Number, "empty house number for country %q", country)
+			assert.NotEmpty(t, a.Street, "empty street for country %q", country)
+			assert.NotEmpty(t, a.Locality, "empty locality for country %q", country)
+			assert.NotEmpty(t, a.PostalCode, "empty postal code for country %q", country)
+		}
+	}
+	assert.ElementsMatch(t, SupportedPlaceCountries(), func() []string {
+		countries := []string{}
+		for country := range addressFormats {
+			countries = append(countries, country)
+		}
+		return countries
+	}(), "every address country should have places")
+	assert.Equal(t, Address{}, r.AddressForCountry("bogus"), "did not return empty address for unknown country")
+}
+
+func TestAddressFormat(t *testing.T) {
+	us := Address{Number: "1600", Street: "Amphitheatre Pkwy", Locality: "Mountain View", Region: "CA", PostalCode: "94043", Country: "US"}
+	assert.Equal(t, "1600 Amphitheatre Pkwy\nMountain View, CA 94043", us.Format(MultiLine))
+	assert.Equal(t, "1600 Amphitheatre Pkwy, Mountain View, CA 94043", us.Format(SingleLine))
+	assert.Equal(t, "1600 Amphitheatre Pkwy\nMOUNTAIN VIEW, CA 94043\nUNITED STATES", us.Format(Postal))
+	assert.Equal(t, us.Format(SingleLine), us.String())
+
+	us.Unit = "12"
+	assert.Equal(t, []string{"1600 Amphitheatre Pkwy Apt 12", "Mountain View, CA 94043"}, us.Lines())
+
+	gb := Address{Unit: "3", Number: "10", Street: "Downing Street", Locality: "London", PostalCode: "SW1A 2AA", Country: "GB"}
+	assert.Equal(t, "Flat 3\n10 Downing Street\nLondon\nSW1A 2AA", gb.Format(MultiLine))
+	assert.Equal(t, "Flat 3\n10 Downing Street\nLONDON\nSW1A 2AA\nUNITED KINGDOM", gb.Format(Postal))
+
+	de := Address{Number: "1", Street: "Platz der Republik", Locality: "Berlin", Region: "BE", PostalCode: "11011", Country: "DE"}
+	assert.Equal(t, "Platz der Republik 1\n11011 Berlin", de.Format(MultiLine))
+
+	fr := Address{Number: "55", Street: "rue du Faubourg Saint-Honoré", Locality: "Paris", Region: "IDF", PostalCode: "75008", Country: "FR"}
+	assert.Equal(t, "55 rue du Faubourg Saint-Honoré\n75008 PARIS\nFRANCE", fr.Format(Postal))
+
+	se := Address{Number: "3", Street: "Slottsbacken", Locality: "Stockholm", PostalCode: "111 30", Country: "SE"}
+	assert.Equal(t, "Slottsbacken 3\nSE-111 30 Stockholm\nSWEDEN", se.Format(Postal))
+
+	br := Address{Number: "1578", Street: "Avenida Paulista", Locality: "São Paulo", Region: "SP", PostalCode: "01310-200", Country: "BR"}
+	assert.Equal(t, "Avenida Paulista, 1578\nSão Paulo-SP\n01310-200", br.Format(MultiLine))
+
+	ca := Address{Number: "24", Street: "Sussex Dr", Locality: "Ottawa", Region: "ON", PostalCode: "K1M 1M4", Country: "CA"}
+	assert.Equal(t, "24 SUSSEX DR\nOTTAWA ON K1M 1M4\nCANADA", ca.Format(Postal))
+
+	partial := Address{Number: "5", Street: "Main St", Locality: "Springfield", Country: "US"}
+	assert.Equal(t, "5 Main St\nSpringfield", partial.Format(MultiLine), "missing fields should not leave separators behind")
+
+	assert.Empty(t, Address{}.Format(MultiLine))
+}
@@ -0,0 +1,471 @@
+package randomdata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// LogLevel is the severity of an application log record.
+type LogLevel int
+
+const (
+	DebugLevel LogLevel = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+// String returns the name of the level, as written by log/slog.
+func (l LogLevel) String() string {
+	switch l {
+	case DebugLevel:
+		return "DEBUG"
+	case InfoLevel:
+		return "INFO"
+	case WarnLevel:
+		return "WARN"
+	case ErrorLevel:
+		return "ERROR"
+	}
+	return "unknown"
+}
+
+// syslogFacility is the facility of the syslog messages, local0.
+const syslogFacility = 16
+
+// syslogSeverity returns the syslog severity of the level (RFC 5424 section 6.2.1).
+func (l LogLevel) syslogSeverity() int {
+	switch l {
+	case DebugLevel:
+		return 7
+	case InfoLevel:
+		return 6
+	case WarnLevel:
+		return 4
+	}
+	return 3
+}
+
+// AppLogFormat is the layout of an application log record.
+type AppLogFormat int
+
+const (
+	// SyslogRFC5424 is the syslog protocol of RFC 5424, trace identifiers being structured data.
+	SyslogRFC5424 AppLogFormat = iota
+	// SyslogRFC3164 is the BSD syslog format of RFC 3164.
+	SyslogRFC3164
+	// SlogJSON is the output of the JSON handler of log/slog.
+	SlogJSON
+	// SlogText is the output of the text handler of log/slog.
+	SlogText
+	// Logfmt is a line of key=value pairs, as written by most logfmt libraries.
+	Logfmt
+	// JournaldExport is the journal export format of systemd, an entry being followed by an empty line.
+	JournaldExport
+)
+
+// String returns the name of the application log format.
+func (f AppLogFormat) String() string {
+	switch f {
+	case SyslogRFC5424:
+		return "RFC 5424 syslog"
+	case SyslogRFC3164:
+		return "RFC 3164 syslog"
+	case SlogJSON:
+		return "slog JSON"
+	case SlogText:
+		return "slog text"
+	case Logfmt:
+		return "logfmt"
+	case JournaldExport:
+		return "journald export"
+	}
+	return "unknown"
+}
+
+// LogAttr is a key-value pair of a log record.
+type LogAttr struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"` // a string, an int, a float64 or a bool
+}
+
+// LogRecord is a record written by an application.
+type LogRecord struct {
+	Time    time.Time `json:"time"`
+	Level   LogLevel  `json:"level"`
+	Service string    `json:"service"`
+	Host    string    `json:"host"`
+	PID     int       `json:"pid"`
+	Message string    `json:"message"`
+	TraceID string    `json:"traceId"` // W3C trace context identifier, empty outside requests
+	SpanID  string    `json:"spanId"`
+	Attrs   []LogAttr `json:"attrs"`
+	Error   string    `json:"error"` // set for error records
+	Stack   string    `json:"stack"` // Go stack trace of some error records
+}
+
+var (
+	logServices = []string{"api-gateway", "auth", "billing", "inventory", "notifications", "orders", "payments", "search"}
+	logTables   = []string{"users", "orders", "payments", "sessions", "products", "invoices"}
+	logErrors   = []string{
+		"context deadline exceeded",
+		"dial tcp 10.0.3.7:5432: connect: connection refused",
+		"pq: deadlock detected",
+		"redis: connection pool timeout",
+		"rpc error: code = Unavailable desc = connection error",
+		"unexpected status 502 from upstream",
+		"json: cannot unmarshal string into Go value of type int64",
+		"x509: certificate has expired or is not yet valid",
+	}
+	logErrorMessages = []string{"request failed", "database query failed", "upstream call failed", "job failed", "could not publish event"}
+	logPackages      = []string{"handler", "service", "repository", "client", "worker"}
+	logTypes         = []string{"Server", "Store", "Client", "Processor", "Handler"}
+	logMethods       = []string{"Create", "Get", "List", "Update", "Delete", "Process", "Handle", "Send"}
+)
+
+// logSource is a running instance of a service.
+type logSource struct {
+	service string
+	host    string
+	pid     int
+}
+
+// logSource returns a random instance of the service, named like a Kubernetes pod.
+func (r *Rand) logSource(service string) logSource {
+	const alphabet = "bcdfghjklmnpqrstvwxz2456789"
+	suffix := make([]byte, 5)
+	for i := range suffix {
+		suffix[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return logSource{
+		service: service,
+		host:    fmt.Sprintf("%s-%s-%s", service, hex.EncodeToString(r.randomBytes(5))[:9], suffix),
+		pid:     r.Number(2, 32768),
+	}
+}
+
+// LogRecord returns a random record written at the supplied time by one of a few services.
+// Most records are informational, errors come with an error message and often a stack trace.
+func (r *Rand) LogRecord(at time.Time) LogRecord {
+	return r.logRecord(at, r.logSource(r.StringFrom(logServices)), r.logLevel(), "")
+}
+
+// logLevel returns a level following the usual distribution of production logs.
+func (r *Rand) logLevel() LogLevel {
+	switch pick := r.Intn(100); {
+	case pick < 15:
+		return DebugLevel
+	case pick < 85:
+		return InfoLevel
+	case pick < 95:
+		return WarnLevel
+	}
+	return ErrorLevel
+}
+
+// LogLine returns a random application log line of the supplied format, for a record written now.
+func (r *Rand) LogLine(format AppLogFormat) string {
+	return r.LogRecord(time.Now()).Format(format)
+}
+
+// logRecord returns a record of the source at the supplied level, an error record reporting cause if not empty.
+func (r *Rand) logRecord(at time.Time, source logSource, level LogLevel, cause string) LogRecord {
+	record := LogRecord{Time: at, Level: level, Service: source.service, Host: source.host, PID: source.pid}
+	if level >= InfoLevel && r.Intn(5) > 0 {
+		record.TraceID = hex.EncodeToString(r.randomBytes(16))
+		record.SpanID = hex.EncodeToString(r.randomBytes(8))
+	}
+	attr := func(key string, value interface{}) {
+		record.Attrs = append(record.Attrs, LogAttr{key, value})
+	}
+	switch level {
+	case DebugLevel:
+		if r.Boolean() {
+			record.Message = "cache lookup"
+			attr("key", r.StringFrom(logTables)+":"+strconv.Itoa(r.Number(1, 100000)))
+			attr("hit", r.Boolean())
+		} else {
+			record.Message = "query executed"
+			attr("table", r.StringFrom(logTables))
+			attr("rows", r.Intn(500))
+			attr("duration_ms", float64(r.Intn(20000))/1000)
+		}
+	case InfoLevel:
+		switch r.Intn(3) {
+		case 0:
+			record.Message = "request completed"
+			method := r.httpMethod()
+			attr("method", method)
+			attr("path", "/api/"+domainLabel(r.Noun())+"/"+strconv.Itoa(r.Number(1, 100000)))
+			attr("status", r.HTTPStatusCode(method))
+			attr("duration_ms", r.Number(1, 800))
+		case 1:
+			record.Message = "user logged in"
+			attr("user_id", r.Number(1, 1000000))
+			attr("method", r.StringFrom([]string{"password", "oauth", "sso"}))
+		default:
+			record.Message = "job finished"
+			attr("job", r.StringFrom([]string{"sync-inventory", "send-digest", "rotate-keys", "cleanup-sessions"}))
+			attr("duration_ms", r.Number(100, 60000))
+		}
+	case WarnLevel:
+		switch r.Intn(3) {
+		case 0:
+			record.Message = "slow query"
+			attr("table", r.StringFrom(logTables))
+			attr("duration_ms", r.Number(1000, 10000))
+		case 1:
+			record.Message = "retrying request"
+			attr("attempt", r.Number(1, 5))
+			attr("backoff_ms", 100<<uint(r.Intn(6)))
+		default:
+			record.Message = "deprecated endpoint called"
+			attr("endpoint", "/v1/"+domainLabel(r.Noun()))
+		}
+	default:
+		record.Message = r.StringFrom(logErrorMessages)
+		record.Error = cause
+		if record.Error == "" {
+			record.Error = r.StringFrom(logErrors)
+		}
+		if r.Intn(3) > 0 {
+			record.Stack = r.goStackTrace(source.service)
+		}
+	}
+	return record
+}
+
+// goStackTrace returns a stack trace of a panicking goroutine of the service.
+func (r *Rand) goStackTrace(service string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "goroutine %d [running]:\n", r.Number(1, 5000))
+	for i, depth := 0, r.Number(3, 8); i < depth; i++ {
+		pkg := r.StringFrom(logPackages)
+		fmt.Fprintf(&b, "example.com/%s/internal/%s.(*%s).%s(0xc%09x, {0x%x, 0xc%09x})\n",
+			service, pkg, r.StringFrom(logTypes), r.StringFrom(logMethods), r.Intn(1<<30), r.Number(0x400000, 0xffffff), r.Intn(1<<30))
+		fmt.Fprintf(&b, "\t/app/internal/%s/%s.go:%d +0x%x\n", pkg, r.StringFrom(logMethods), r.Number(20, 600), r.Number(0x20, 0x400))
+	}
+	fmt.Fprintf(&b, "created by net/http.(*Server).Serve in goroutine 1\n\t/usr/local/go/src/net/http/server.go:3285 +0x4b4")
+	return b.String()
+}
+
+// Format returns the record in the supplied format, without the trailing newline.
+// Multi-line stack traces are kept on one line by escaping, except in journal entries which hold binary fields.
+func (rec LogRecord) Format(format AppLogFormat) string {
+	switch format {
+	case SyslogRFC5424:
+		sd := "-"
+		if rec.TraceID != "" {
+			sd = fmt.Sprintf(`[trace@32473 trace_id="%s" span_id="%s"]`, rec.TraceID, rec.SpanID)
+		}
+		return fmt.Sprintf("<%d>1 %s %s %s %d - %s %s", 8*syslogFacility+rec.Level.syslogSeverity(),
+			rec.Time.Format("2006-01-02T15:04:05.000000Z07:00"), rec.Host, rec.Service, rec.PID, sd, rec.syslogMessage())
+	case SyslogRFC3164:
+		return fmt.Sprintf("<%d>%s %s %s[%d]: %s", 8*syslogFacility+rec.Level.syslogSeverity(),
+			rec.Time.Format(time.Stamp), rec.Host, rec.Service, rec.PID, rec.syslogMessage())
+	case SlogJSON:
+		// Like log/slog, the JSON is not HTML-escaped.
+		var b bytes.Buffer
+		encoder := json.NewEncoder(&b)
+		encoder.SetEscapeHTML(false)
+		write := func(v interface{}) {
+			if encoder.Encode(v) == nil {
+				b.Truncate(b.Len() - 1)
+			}
+		}
+		b.WriteString("{")
+		for i, field := range rec.fields("time", "level", "msg", "error", "stack", rec.Time.Format(time.RFC3339Nano), rec.Level.String()) {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			write(field.Key)
+			b.WriteString(":")
+			write(field.Value)
+		}
+		b.WriteString("}")
+		return b.String()
+	case SlogText, Logfmt:
+		fields := rec.fields("time", "level", "msg", "error", "stack", rec.Time.Format("2006-01-02T15:04:05.000Z07:00"), rec.Level.String())
+		if format == Logfmt {
+			fields = rec.fields("ts", "level", "msg", "err", "stacktrace", rec.Time.Format(time.RFC3339Nano), strings.ToLower(rec.Level.String()))
+		}
+		pairs := make([]string, len(fields))
+		for i, field := range fields {
+			pairs[i] = field.Key + "=" + logfmtValue(field.Value)
+		}
+		return strings.Join(pairs, " ")
+	case JournaldExport:
+		var b strings.Builder
+		field := func(name, value string) {
+			if !strings.Contains(value, "\n") {
+				b.WriteString(name + "=" + value + "\n")
+				return
+			}
+			// Values holding a newline are written as binary fields, prefixed by their little-endian 64-bit size.
+			size := make([]byte, 8)
+			binary.LittleEndian.PutUint64(size, uint64(len(value)))
+			b.WriteString(name + "\n")
+			b.Write(size)
+			b.WriteString(value + "\n")
+		}
+		field("__REALTIME_TIMESTAMP", strconv.FormatInt(rec.Time.UnixNano()/1000, 10))
+		field("_HOSTNAME", rec.Host)
+		field("SYSLOG_IDENTIFIER", rec.Service)
+		field("_PID", strconv.Itoa(rec.PID))
+		field("PRIORITY", strconv.Itoa(rec.Level.syslogSeverity()))
+		field("MESSAGE", rec.Message)
+		if rec.TraceID != "" {
+			field("TRACE_ID", rec.TraceID)
+			field("SPAN_ID", rec.SpanID)
+		}
+		for _, attr := range rec.Attrs {
+			field(journalFieldName(attr.Key), fmt.Sprint(attr.Value))
+		}
+		if rec.Error != "" {
+			field("ERROR", rec.Error)
+		}
+		if rec.Stack != "" {
+			field("STACK_TRACE", rec.Stack)
+		}
+		return strings.TrimSuffix(b.String(), "\n")
+	}
+	return ""
+}
+
+// fields lists the content of the record as key-value pairs, in the order of log/slog handlers.
+func (rec LogRecord) fields(timeKey, levelKey, messageKey, errorKey, stackKey, at, level string) []LogAttr {
+	fields := []LogAttr{{timeKey, at}, {levelKey, level}, {messageKey, rec.Message}, {"service", rec.Service}, {"host", rec.Host}}
+	if rec.TraceID != "" {
+		fields = append(fields, LogAttr{"trace_id", rec.TraceID}, LogAttr{"span_id", rec.SpanID})
+	}
+	fields = append(fields, rec.Attrs...)
+	if rec.Error != "" {
+		fields = append(fields, LogAttr{errorKey, rec.Error})
+	}
+	if rec.Stack != "" {
+		fields = append(fields, LogAttr{stackKey, rec.Stack})
+	}
+	return fields
+}
+
+// syslogMessage returns the message followed by its attributes and error, on one line.
+func (rec LogRecord) syslogMessage() string {
+	msg := rec.Message
+	for _, attr := range rec.Attrs {
+		msg += " " + attr.Key + "=" + logfmtValue(attr.Value)
+	}
+	if rec.Error != "" {
+		msg += ": " + rec.Error
+	}
+	return msg
+}
+
+// logfmtValue formats a value like the text handler of log/slog: strings are quoted when they are empty
+// or hold spaces, quotes, equal signs or non-printable characters.
+func logfmtValue(value interface{}) string {
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	if s == "" || strings.IndexFunc(s, func(c rune) bool { return c == '"' || c == '=' || unicode.IsSpace(c) || !unicode.IsPrint(c) }) >= 0 {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// journalFieldName turns a key into a journal field name: upper case letters, digits and underscores.
+func journalFieldName(key string) string {
+	return strings.Map(func(c rune) rune {
+		if c >= 'a' && c <= 'z' {
+			return c - 'a' + 'A'
+		}
+		if c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' {
+			return c
+		}
+		return '_'
+	}, key)
+}
+
+// ErrorBurst is an incident during which a share of the records of a service are errors reporting the same cause.
+type ErrorBurst struct {
+	// Offset is the start of the burst after the start of the log.
+	Offset time.Duration
+	// Duration is the length of the burst.
+	Duration time.Duration
+	// ErrorRate is the share of error records during the burst, between 0 and 1. The zero value means 0.5.
+	ErrorRate float64
+	// Service is the failing service. The zero value means every service.
+	Service string
+	// Error is the error reported by the failing records. The zero value means a random one.
+	Error string
+}
+
+// AppLogOptions drives WriteAppLog.
+type AppLogOptions struct {
+	// Format is the layout of the records.
+	Format AppLogFormat
+	// Start is the time of the first simulated second. The zero value means time.Now().
+	Start time.Time
+	// PerSecond is the number of records of each simulated second. The zero value means 10.
+	PerSecond int
+	// Seconds is the number of simulated seconds. The zero value means 60.
+	Seconds int
+	// Services are the names of the services writing records. The zero value means a few typical ones.
+	Services []string
+	// Bursts are the incidents to inject.
+	Bursts []ErrorBurst
+}
+
+// WriteAppLog writes the records of a set of services to w, without waiting for the simulated time to pass.
+// Each service runs on one host, and each simulated second holds PerSecond records in order.
+// During error bursts the failing service mostly reports the same error, so that a seed replays the same incident.
+// It stops at the first error returned by w.
+func (r *Rand) WriteAppLog(w io.Writer, opts AppLogOptions) error {
+	start, perSecond, seconds := simulatedClock(opts.Start, opts.PerSecond, opts.Seconds)
+	services := opts.Services
+	if len(services) == 0 {
+		services = logServices
+	}
+	sources := make([]logSource, len(services))
+	for i, service := range services {
+		sources[i] = r.logSource(service)
+	}
+	bursts := append([]ErrorBurst(nil), opts.Bursts...)
+	for i := range bursts {
+		if bursts[i].ErrorRate <= 0 {
+			bursts[i].ErrorRate = 0.5
+		}
+		if bursts[i].Error == "" {
+			bursts[i].Error = r.StringFrom(logErrors)
+		}
+	}
+
+	return r.simulatedOffsets(perSecond, seconds, func(elapsed time.Duration) error {
+		source := sources[r.Intn(len(sources))]
+		level, cause := r.logLevel(), ""
+		for _, burst := range bursts {
+			if elapsed < burst.Offset || elapsed >= burst.Offset+burst.Duration || burst.Service != "" && burst.Service != source.service {
+				continue
+			}
+			if r.Float64() < burst.ErrorRate {
+				level, cause = ErrorLevel, burst.Error
+			}
+			break
+		}
+		record := r.logRecord(start.Add(elapsed), source, level, cause)
+		line := record.Format(opts.Format) + "\n"
+		if opts.Format == JournaldExport {
+			line += "\n"
+		}
+		_, err := io.WriteString(w, line)
+		return err
+	})
+}
@@ -0,0 +1,34 @@
+//go:build go1.21
+
+package randomdata
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlogJSONMatchesHandler(t *testing.T) {
+	record := LogRecord{
+		Time: time.Date(2024, 5, 3, 9, 7, 1, 250000000, time.UTC), Level: WarnLevel, Service: "orders", Host: "orders-1", PID: 42,
+		Message: "slow <query>", TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7",
+		Attrs: []LogAttr{{"path", "/api/orders?a=1&b=2"}, {"status", 200}, {"note", `say "hi"`}},
+		Error: "took > 2s", Stack: "goroutine 1 [running]:\nmain.main()",
+	}
+	levels := map[LogLevel]slog.Level{DebugLevel: slog.LevelDebug, InfoLevel: slog.LevelInfo, WarnLevel: slog.LevelWarn, ErrorLevel: slog.LevelError}
+	for _, r := range []LogRecord{record, FromSeed(1234).LogRecord(record.Time)} {
+		var buf bytes.Buffer
+		handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+		expected := slog.NewRecord(r.Time, levels[r.Level], r.Message, 0)
+		for _, field := range r.fields("time", "level", "msg", "error", "stack", "", "")[3:] {
+			expected.AddAttrs(slog.Any(field.Key, field.Value))
+		}
+		assert.NoError(t, handler.Handle(context.Background(), expected))
+		assert.Equal(t, strings.TrimSuffix(buf.String(), "\n"), r.Format(SlogJSON))
+	}
+}
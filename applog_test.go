@@ -0,0 +1,200 @@
+package randomdata
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var appLogPatterns = map[AppLogFormat]*regexp.Regexp{
+	SyslogRFC5424: regexp.MustCompile(`^<1[3-3][1-5]>1 \d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{6}(Z|[+-]\d{2}:\d{2}) \S+ \S+ \d+ - ` +
+		`(-|\[trace@32473 trace_id="[0-9a-f]{32}" span_id="[0-9a-f]{16}"\]) \S.*$`),
+	SyslogRFC3164: regexp.MustCompile(`^<1[3-3][1-5]>[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2} \S+ [a-z-]+\[\d+\]: \S.*$`),
+	SlogText:      regexp.MustCompile(`^time=\S+ level=(DEBUG|INFO|WARN|ERROR) msg=("[^"]+"|\S+) service=\S+ host=\S+( \w+=("(\\.|[^"\\])*"|[^"\s]+))*$`),
+	Logfmt:        regexp.MustCompile(`^ts=\S+ level=(debug|info|warn|error) msg=("[^"]+"|\S+) service=\S+ host=\S+( \w+=("(\\.|[^"\\])*"|[^"\s]+))*$`),
+}
+
+func TestLogLine(t *testing.T) {
+	r := FromSeed(1234)
+	for format, pattern := range appLogPatterns {
+		for i := 0; i < 300; i++ {
+			line := r.LogLine(format)
+			assert.Regexp(t, pattern, line, "invalid %v line", format)
+		}
+	}
+	for i := 0; i < 300; i++ {
+		line := r.LogLine(SlogJSON)
+		assert.True(t, strings.HasPrefix(line, `{"time":`), line)
+		var object map[string]interface{}
+		if assert.NoError(t, json.Unmarshal([]byte(line), &object), line) {
+			assert.Contains(t, []string{"DEBUG", "INFO", "WARN", "ERROR"}, object["level"])
+			assert.NotEmpty(t, object["msg"])
+			if object["level"] == "ERROR" {
+				assert.NotEmpty(t, object["error"])
+			}
+		}
+	}
+}
+
+func TestLogRecord(t *testing.T) {
+	r := FromSeed(1234)
+	levels := map[LogLevel]int{}
+	stacks := 0
+	for i := 0; i < 2000; i++ {
+		record := r.LogRecord(time.Now())
+		levels[record.Level]++
+		assert.NotEmpty(t, record.Message)
+		assert.True(t, strings.HasPrefix(record.Host, record.Service+"-"), record.Host)
+		assert.Equal(t, record.Level == ErrorLevel, record.Error != "")
+		if record.TraceID != "" {
+			assert.Regexp(t, `^[0-9a-f]{32}$`, record.TraceID)
+			assert.Regexp(t, `^[0-9a-f]{16}$`, record.SpanID)
+		}
+		if record.Stack != "" {
+			stacks++
+			assert.Regexp(t, `^goroutine \d+ \[running\]:\n(\S+\(.*\)\n\t/\S+\.go:\d+ \+0x[0-9a-f]+\n)+created by `, record.Stack)
+		}
+	}
+	assert.Greater(t, levels[InfoLevel], levels[DebugLevel])
+	assert.Greater(t, levels[WarnLevel], levels[ErrorLevel])
+	assert.NotZero(t, levels[ErrorLevel])
+	assert.NotZero(t, stacks)
+}
+
+func TestLogRecordFormat(t *testing.T) {
+	record := LogRecord{
+		Time: time.Date(2024, 5, 3, 9, 7, 1, 250000000, time.UTC), Level: ErrorLevel, Service: "orders", Host: "orders-1", PID: 42,
+		Message: "request failed", TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7",
+		Attrs: []LogAttr{{"path", "/api/orders"}, {"status", 502}, {"note", `say "hi"`}, {"query", "a<b&c>d"}},
+		Error: "unexpected status 502", Stack: "goroutine 1 [running]:\nmain.main()",
+	}
+	assert.Equal(t, `<131>1 2024-05-03T09:07:01.250000Z orders-1 orders 42 - [trace@32473 trace_id="4bf92f3577b34da6a3ce929d0e0e4736" `+
+		`span_id="00f067aa0ba902b7"] request failed path=/api/orders status=502 note="say \"hi\"" query=a<b&c>d: unexpected status 502`, record.Format(SyslogRFC5424))
+	assert.Equal(t, `<131>May  3 09:07:01 orders-1 orders[42]: request failed path=/api/orders status=502 note="say \"hi\"" query=a<b&c>d: unexpected status 502`,
+		record.Format(SyslogRFC3164))
+	assert.Equal(t, `{"time":"2024-05-03T09:07:01.25Z","level":"ERROR","msg":"request failed","service":"orders","host":"orders-1",`+
+		`"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736","span_id":"00f067aa0ba902b7","path":"/api/orders","status":502,"note":"say \"hi\"","query":"a<b&c>d",`+
+		`"error":"unexpected status 502","stack":"goroutine 1 [running]:\nmain.main()"}`, record.Format(SlogJSON))
+	assert.Equal(t, `time=2024-05-03T09:07:01.250Z level=ERROR msg="request failed" service=orders host=orders-1 `+
+		`trace_id=4bf92f3577b34da6a3ce929d0e0e4736 span_id=00f067aa0ba902b7 path=/api/orders status=502 note="say \"hi\"" query=a<b&c>d `+
+		`error="unexpected status 502" stack="goroutine 1 [running]:\nmain.main()"`, record.Format(SlogText))
+	assert.Equal(t, `ts=2024-05-03T09:07:01.25Z level=error msg="request failed" service=orders host=orders-1 `+
+		`trace_id=4bf92f3577b34da6a3ce929d0e0e4736 span_id=00f067aa0ba902b7 path=/api/orders status=502 note="say \"hi\"" query=a<b&c>d `+
+		`err="unexpected status 502" stacktrace="goroutine 1 [running]:\nmain.main()"`, record.Format(Logfmt))
+
+	fields := parseJournalEntry(t, bufio.NewReader(strings.NewReader(record.Format(JournaldExport)+"\n\n")))
+	assert.Equal(t, map[string]string{
+		"__REALTIME_TIMESTAMP": "1714727221250000", "_HOSTNAME": "orders-1", "SYSLOG_IDENTIFIER": "orders", "_PID": "42",
+		"PRIORITY": "3", "MESSAGE": "request failed", "TRACE_ID": "4bf92f3577b34da6a3ce929d0e0e4736", "SPAN_ID": "00f067aa0ba902b7",
+		"PATH": "/api/orders", "STATUS": "502", "NOTE": `say "hi"`, "QUERY": "a<b&c>d", "ERROR": "unexpected status 502",
+		"STACK_TRACE": "goroutine 1 [running]:\nmain.main()",
+	}, fields)
+}
+
+func TestWriteAppLog(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	opts := AppLogOptions{
+		Format: SlogJSON, Start: start, PerSecond: 50, Seconds: 30,
+		Bursts: []ErrorBurst{{Offset: 10 * time.Second, Duration: 5 * time.Second, ErrorRate: 0.9, Service: "payments", Error: "card processor unreachable"}},
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, FromSeed(1234).WriteAppLog(&buf, opts))
+	var replay bytes.Buffer
+	assert.NoError(t, FromSeed(1234).WriteAppLog(&replay, opts))
+	assert.Equal(t, buf.String(), replay.String())
+
+	var last time.Time
+	lines, burstErrors, burstRecords, hosts := 0, 0, 0, map[string]map[string]bool{}
+	scanner := bufio.NewScanner(&buf)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var object struct {
+			Time    time.Time `json:"time"`
+			Level   string    `json:"level"`
+			Service string    `json:"service"`
+			Host    string    `json:"host"`
+			Error   string    `json:"error"`
+		}
+		if !assert.NoError(t, json.Unmarshal(scanner.Bytes(), &object)) {
+			continue
+		}
+		lines++
+		assert.False(t, object.Time.Before(last))
+		last = object.Time
+		if hosts[object.Service] == nil {
+			hosts[object.Service] = map[string]bool{}
+		}
+		hosts[object.Service][object.Host] = true
+		inBurst := !object.Time.Before(start.Add(10*time.Second)) && object.Time.Before(start.Add(15*time.Second))
+		if object.Error == "card processor unreachable" {
+			assert.True(t, inBurst && object.Service == "payments", "unexpected burst error at %v in %s", object.Time, object.Service)
+		}
+		if inBurst && object.Service == "payments" {
+			burstRecords++
+			if object.Error == "card processor unreachable" {
+				burstErrors++
+			}
+		}
+	}
+	assert.Equal(t, 50*30, lines)
+	for service, names := range hosts {
+		assert.Len(t, names, 1, "service %s runs on several hosts", service)
+	}
+	assert.NotZero(t, burstRecords)
+	assert.Greater(t, float64(burstErrors)/float64(burstRecords), 0.75)
+
+	buf.Reset()
+	assert.NoError(t, FromSeed(1234).WriteAppLog(&buf, AppLogOptions{Format: JournaldExport, Start: start, PerSecond: 5, Seconds: 2, Services: []string{"worker"}}))
+	reader := bufio.NewReader(&buf)
+	for i := 0; i < 10; i++ {
+		fields := parseJournalEntry(t, reader)
+		assert.Equal(t, "worker", fields["SYSLOG_IDENTIFIER"])
+	}
+	_, err := reader.ReadByte()
+	assert.Equal(t, io.EOF, err)
+
+	assert.EqualError(t, FromSeed(1234).WriteAppLog(failingWriter{}, AppLogOptions{}), "disk full")
+}
+
+func TestLogfmtValue(t *testing.T) {
+	assert.Equal(t, "plain", logfmtValue("plain"))
+	assert.Equal(t, `""`, logfmtValue(""))
+	assert.Equal(t, `"a b"`, logfmtValue("a b"))
+	assert.Equal(t, `"a=b"`, logfmtValue("a=b"))
+	assert.Equal(t, `"a\nb"`, logfmtValue("a\nb"))
+	assert.Equal(t, "42", logfmtValue(42))
+	assert.Equal(t, "true", logfmtValue(true))
+	assert.Equal(t, "1.5", logfmtValue(1.5))
+}
+
+// parseJournalEntry reads an entry of the journal export format, up to the empty line ending it.
+func parseJournalEntry(t *testing.T, reader *bufio.Reader) map[string]string {
+	fields := map[string]string{}
+	for {
+		line, err := reader.ReadString('\n')
+		if !assert.NoError(t, err) || line == "\n" {
+			return fields
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if i := strings.Index(line, "="); i >= 0 {
+			fields[line[:i]] = line[i+1:]
+			continue
+		}
+		size := make([]byte, 8)
+		_, err = io.ReadFull(reader, size)
+		assert.NoError(t, err)
+		value := make([]byte, binary.LittleEndian.Uint64(size)+1)
+		_, err = io.ReadFull(reader, value)
+		assert.NoError(t, err)
+		assert.Equal(t, byte('\n'), value[len(value)-1])
+		fields[line] = string(value[:len(value)-1])
+	}
+}
@@ -0,0 +1,135 @@
+package randomdata
+
+import (
+	"sort"
+	"strings"
+)
+
+// CountryInfo gathers the codes and conventions of a country.
+type CountryInfo struct {
+	Name        string   `json:"name"`
+	Alpha2      string   `json:"alpha2"`      // ISO 3166-1 alpha-2 code
+	Alpha3      string   `json:"alpha3"`      // ISO 3166-1 alpha-3 code
+	Numeric     string   `json:"numeric"`     // ISO 3166-1 numeric code
+	Currencies  []string `json:"currencies"`  // ISO 4217 codes, the most used first
+	CallingCode string   `json:"callingCode"` // e.g. "+49", empty for uninhabited territories
+	Languages   []string `json:"languages"`   // official languages, the most spoken first
+	Locales     []string `json:"locales"`     // BCP 47 tags of the official languages in the country
+	Timezones   []string `json:"timezones"`   // tz database names
+	TLD         string   `json:"tld"`         // country code top-level domain, e.g. ".de"
+	Flag        string   `json:"flag"`        // flag emoji
+}
+
+// nanpAreaCodes are the area codes of the members of the North American Numbering Plan sharing
+// the +1 calling code, apart from the US and Canada.
+var nanpAreaCodes = map[string][]string{
+	"AG": {"268"}, "AI": {"264"}, "AS": {"684"}, "BB": {"246"}, "BM": {"441"}, "BS": {"242"},
+	"DM": {"767"}, "DO": {"809", "829", "849"}, "GD": {"473"}, "GU": {"671"}, "JM": {"876", "658"},
+	"KN": {"869"}, "KY": {"345"}, "LC": {"758"}, "MP": {"670"}, "MS": {"664"}, "PR": {"787", "939"},
+	"SX": {"721"}, "TC": {"649"}, "TT": {"868"}, "VC": {"784"}, "VG": {"284"}, "VI": {"340"},
+}
+
+// CountryInfo returns the information of a random country.
+// All the fields are consistent with each other, e.g. a German country comes with EUR and +49.
+func (r *Rand) CountryInfo() CountryInfo {
+	info, _ := LookupCountry(r.StringFrom(SupportedCountries()))
+	return info
+}
+
+// LookupCountry returns the information of the country with the supplied ISO 3166-1 alpha-2 or alpha-3 code.
+func LookupCountry(code string) (CountryInfo, bool) {
+	code = strings.ToUpper(code)
+	if len(code) == 3 {
+		for alpha2, entry := range jsonData.CountryInfo {
+			if entry.Alpha3 == code {
+				code = alpha2
+				break
+			}
+		}
+	}
+	entry, ok := jsonData.CountryInfo[code]
+	if !ok {
+		return CountryInfo{}, false
+	}
+	info := CountryInfo{
+		Name:        entry.Name,
+		Alpha2:      code,
+		Alpha3:      entry.Alpha3,
+		Numeric:     entry.Numeric,
+		Currencies:  append([]string(nil), entry.Currencies...),
+		CallingCode: entry.CallingCode,
+		Languages:   append([]string(nil), entry.Languages...),
+		Timezones:   append([]string(nil), entry.Timezones...),
+		TLD:         "." + strings.ToLower(code),
+		Flag:        string([]rune{rune(code[0]-'A') + 0x1F1E6, rune(code[1]-'A') + 0x1F1E6}),
+	}
+	// The United Kingdom kept .uk, registered before ISO 3166-1 was used for top-level domains.
+	if code == "GB" {
+		info.TLD = ".uk"
+	}
+	for _, language := range entry.Languages {
+		info.Locales = append(info.Locales, language+"-"+code)
+	}
+	return info, true
+}
+
+// SupportedCountries returns the sorted ISO 3166-1 alpha-2 codes of the countries known by LookupCountry.
+func SupportedCountries() []string {
+	countries := make([]string, 0, len(jsonData.CountryInfo))
+	for country := range jsonData.CountryInfo {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
+}
+
+// CurrencyForCountry returns a random currency, under ISO 4217 format, used in the supplied 2-letter country code.
+// The main currency is picked most of the time.
+// If the country is not supported it will return an empty string.
+func (r *Rand) CurrencyForCountry(countrycode string) string {
+	currencies := jsonData.CountryInfo[strings.ToUpper(countrycode)].Currencies
+	if len(currencies) > 1 && r.Intn(4) > 0 {
+		return currencies[0]
+	}
+	return r.StringFrom(currencies)
+}
+
+// LocaleForCountry returns a random locale, as a BCP 47 tag, of an official language of the supplied 2-letter
+// country code, e.g. "fr-CH".
+// If the country is not supported it will return an empty string.
+func (r *Rand) LocaleForCountry(countrycode string) string {
+	countrycode = strings.ToUpper(countrycode)
+	language := r.StringFrom(jsonData.CountryInfo[countrycode].Languages)
+	if language == "" {
+		return ""
+	}
+	return language + "-" + countrycode
+}
+
+// TimezoneForCountry returns a random time zone of the supplied 2-letter country code.
+// If the country is not supported it will return an empty string.
+func (r *Rand) TimezoneForCountry(countrycode string) string {
+	return r.StringFrom(jsonData.CountryInfo[strings.ToUpper(countrycode)].Timezones)
+}
+
+// PhoneNumberForCountry returns a random phone number with the calling code of the supplied 2-letter country code.
+// Countries of SupportedPhoneCountries get a landline or a mobile following their numbering plan,
+// members of the North American Numbering Plan get one of their area codes.
+// If the country is not supported it will return an empty string.
+func (r *Rand) PhoneNumberForCountry(countrycode string) string {
+	countrycode = strings.ToUpper(countrycode)
+	if _, ok := numberingPlans[countrycode]; ok {
+		return r.PhoneNumberFor(countrycode, []PhoneKind{Landline, Mobile}[r.Intn(2)]).International()
+	}
+	callingCode := strings.TrimPrefix(jsonData.CountryInfo[countrycode].CallingCode, "+")
+	if callingCode == "" {
+		return ""
+	}
+	if areaCodes, ok := nanpAreaCodes[countrycode]; ok {
+		return "+1 " + r.StringFrom(areaCodes) + " " + r.BoundedDigits(3, 200, 999) + " " + r.Digits(4)
+	}
+	if callingCode == "1" {
+		return "+1 " + r.BoundedDigits(3, 201, 989) + " " + r.BoundedDigits(3, 200, 999) + " " + r.Digits(4)
+	}
+	return r.phoneNumber(callingCode)
+}
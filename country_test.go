@@ -0,0 +1,102 @@
+package randomdata
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountryInfo(t *testing.T) {
+	r := FromSeed(1234)
+	assert.Len(t, SupportedCountries(), 249)
+	for i := 0; i < 500; i++ {
+		info := r.CountryInfo()
+		assert.Regexp(t, `^[A-Z]{2}$`, info.Alpha2)
+		assert.Regexp(t, `^[A-Z]{3}$`, info.Alpha3)
+		assert.Regexp(t, `^\d{3}$`, info.Numeric)
+		assert.NotEmpty(t, info.Name)
+		assert.Regexp(t, `^\.[a-z]{2}$`, info.TLD)
+		assert.Len(t, []rune(info.Flag), 2)
+		if info.CallingCode != "" {
+			assert.Regexp(t, `^\+\d{1,3}$`, info.CallingCode)
+		}
+		for _, currency := range info.Currencies {
+			assert.Regexp(t, `^[A-Z]{3}$`, currency)
+		}
+		assert.Len(t, info.Locales, len(info.Languages))
+		for _, locale := range info.Locales {
+			assert.True(t, strings.HasSuffix(locale, "-"+info.Alpha2), "locale %q does not match %q", locale, info.Alpha2)
+		}
+		for _, tz := range info.Timezones {
+			assert.Contains(t, tz, "/")
+		}
+	}
+}
+
+func TestLookupCountry(t *testing.T) {
+	de, ok := LookupCountry("DE")
+	assert.True(t, ok)
+	assert.Equal(t, CountryInfo{
+		Name:        "Germany",
+		Alpha2:      "DE",
+		Alpha3:      "DEU",
+		Numeric:     "276",
+		Currencies:  []string{"EUR"},
+		CallingCode: "+49",
+		Languages:   []string{"de"},
+		Locales:     []string{"de-DE"},
+		Timezones:   []string{"Europe/Berlin", "Europe/Busingen"},
+		TLD:         ".de",
+		Flag:        "🇩🇪",
+	}, de)
+
+	ch, ok := LookupCountry("che")
+	assert.True(t, ok)
+	assert.Equal(t, "CH", ch.Alpha2)
+	assert.Equal(t, []string{"de-CH", "fr-CH", "it-CH", "rm-CH"}, ch.Locales)
+
+	gb, _ := LookupCountry("GB")
+	assert.Equal(t, ".uk", gb.TLD)
+	assert.Equal(t, "🇬🇧", gb.Flag)
+
+	us, _ := LookupCountry("US")
+	assert.Equal(t, "840", us.Numeric)
+	assert.Contains(t, us.Timezones, "America/Los_Angeles")
+
+	_, ok = LookupCountry("XX")
+	assert.False(t, ok)
+}
+
+func TestCountryScopedGenerators(t *testing.T) {
+	r := FromSeed(1234)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, "JPY", r.CurrencyForCountry("JP"))
+		assert.Contains(t, []string{"PAB", "USD"}, r.CurrencyForCountry("PA"))
+		assert.Contains(t, []string{"de-CH", "fr-CH", "it-CH", "rm-CH"}, r.LocaleForCountry("ch"))
+		assert.Contains(t, []string{"Europe/Berlin", "Europe/Busingen"}, r.TimezoneForCountry("DE"))
+		assert.Regexp(t, `^\+49 \d`, r.PhoneNumberForCountry("DE"))
+		assert.Regexp(t, `^\+1 684 [2-9]\d{2} \d{4}$`, r.PhoneNumberForCountry("AS"))
+		assert.Regexp(t, `^\+1 [2-9]\d{2} [2-9]\d{2} \d{4}$`, r.PhoneNumberForCountry("US"))
+	}
+
+	phone := regexp.MustCompile(`^\+\d{1,3}( \d+)+$`)
+	for _, country := range SupportedCountries() {
+		info, _ := LookupCountry(country)
+		if info.CallingCode != "" {
+			number := r.PhoneNumberForCountry(country)
+			assert.Regexp(t, phone, number)
+			assert.True(t, strings.HasPrefix(number, info.CallingCode+" "), "%q does not start with %q", number, info.CallingCode)
+		}
+		if len(info.Currencies) > 0 {
+			assert.Contains(t, info.Currencies, r.CurrencyForCountry(country))
+		}
+	}
+
+	assert.Empty(t, r.CurrencyForCountry("XX"))
+	assert.Empty(t, r.LocaleForCountry("XX"))
+	assert.Empty(t, r.TimezoneForCountry("XX"))
+	assert.Empty(t, r.PhoneNumberForCountry("XX"))
+	assert.Empty(t, r.PhoneNumberForCountry("BV"))
+}
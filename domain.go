@@ -0,0 +1,311 @@
+package randomdata
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Top-level domains obtained from:
+// * https://data.iana.org/TLD/tlds-alpha-by-domain.txt
+// * https://publicsuffix.org/list/ (second-level domains under country codes)
+var topLevelDomains = []string{
+	"ai", "app", "at", "be", "biz", "blog", "ca", "ch", "cloud", "cn", "co", "co.jp", "co.nz", "co.uk", "co.za",
+	"com", "com.au", "com.br", "com.mx", "de", "dev", "dk", "es", "eu", "fi", "fr", "ie", "in", "info", "io", "it",
+	"jp", "net", "nl", "no", "online", "org", "org.uk", "pl", "pt", "ru", "se", "shop", "site", "store", "tech",
+	"us", "xyz",
+}
+
+// idnTopLevelDomains are internationalized top-level domains, in Unicode.
+var idnTopLevelDomains = []string{"рф", "онлайн", "中国", "한국", "ελ"}
+
+// idnWords are used as labels of internationalized domain names.
+var idnWords = []string{
+	"bücher", "münchen", "köln", "zürich", "straße", "café", "élan", "españa", "niño", "ação", "smörgås", "ærø",
+	"çiçek", "şeker", "москва", "пример", "книга", "δοκιμή", "ελλάδα", "例子", "中文", "テスト", "日本", "한국어",
+}
+
+// subdomainLabels are the labels commonly found in front of a registered domain.
+var subdomainLabels = []string{
+	"api", "app", "auth", "blog", "cdn", "dev", "docs", "eu", "ftp", "img", "internal", "m", "mail", "ns1", "ns2",
+	"shop", "smtp", "staging", "static", "status", "us-east-1", "vpn", "web", "www",
+}
+
+// HostOptions drives the generation of a host name.
+type HostOptions struct {
+	// Subdomains is the number of labels in front of the registered domain, e.g. 2 for "api.eu.bluefox.io".
+	Subdomains int
+	// IDN makes the registered domain an internationalized domain name, e.g. "xn--bcher-kva.de" for "bücher.de".
+	IDN bool
+	// Unicode keeps the internationalized labels in Unicode instead of encoding them with punycode.
+	Unicode bool
+	// TLD is the top-level domain, e.g. "io" or "co.uk". The zero value means a random one.
+	TLD string
+}
+
+// DomainName returns a random registered domain name, made of a label and a real top-level domain,
+// e.g. "bluefox.io" or "harris.co.uk".
+func (r *Rand) DomainName() string {
+	return r.Host(HostOptions{})
+}
+
+// Hostname returns a random fully qualified host name, a domain name behind one to three subdomains,
+// e.g. "api.eu.bluefox.io".
+func (r *Rand) Hostname() string {
+	return r.Host(HostOptions{Subdomains: r.Number(1, 4)})
+}
+
+// Host returns a random host name following the supplied options.
+// Labels follow the letters, digits and hyphen rule of RFC 1035 and RFC 1123: they are at most 63 characters long
+// and neither start nor end with a hyphen.
+func (r *Rand) Host(opts HostOptions) string {
+	tld := strings.ToLower(strings.Trim(opts.TLD, "."))
+	if tld == "" {
+		tld = r.StringFrom(topLevelDomains)
+		if opts.IDN && r.Intn(3) == 0 {
+			tld = r.StringFrom(idnTopLevelDomains)
+		}
+	}
+	labels := make([]string, 0, opts.Subdomains+2)
+	for i := 0; i < opts.Subdomains; i++ {
+		if i == 0 || r.Intn(2) == 0 {
+			labels = append(labels, r.StringFrom(subdomainLabels))
+		} else {
+			labels = append(labels, r.hostLabel())
+		}
+	}
+	if opts.IDN {
+		labels = append(labels, r.StringFrom(idnWords))
+	} else {
+		labels = append(labels, r.hostLabel())
+	}
+	labels = append(labels, strings.Split(tld, ".")...)
+	if !opts.Unicode {
+		for i, label := range labels {
+			labels[i] = idnaLabel(label)
+		}
+	}
+	host := strings.Join(labels, ".")
+	// Drop the leftmost labels of overly deep names to honour the 253 characters limit.
+	for len(host) > 253 && strings.Count(host, ".") > 1 {
+		host = host[strings.Index(host, ".")+1:]
+	}
+	return host
+}
+
+// hostLabel returns a label made of English words, e.g. "bluefox", "blue-fox" or "bluefox42".
+func (r *Rand) hostLabel() string {
+	adjective, noun := domainLabel(r.Adjective()), domainLabel(r.Noun())
+	switch r.Intn(4) {
+	case 0:
+		return adjective + "-" + noun
+	case 1:
+		return noun + strconv.Itoa(r.Number(1, 100))
+	case 2:
+		return noun
+	}
+	return adjective + noun
+}
+
+// isHostname tells whether s is a host name made of letters, digits and hyphen labels (RFC 1123),
+// internationalized labels being encoded with punycode.
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	labels := strings.Split(s, ".")
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	// The top-level domain is never all-numeric, so that host names can't be mistaken for IPv4 addresses.
+	_, err := strconv.Atoi(labels[len(labels)-1])
+	return err != nil
+}
+
+// idnaLabel returns the ASCII form of a label: unchanged if it is ASCII, otherwise punycode behind the "xn--" prefix.
+func idnaLabel(label string) string {
+	if utf8.RuneCountInString(label) == len(label) {
+		return label
+	}
+	return "xn--" + punycode(label)
+}
+
+// punycode encodes a string with the Bootstring parameters of RFC 3492.
+func punycode(s string) string {
+	const (
+		base, tMin, tMax, skew, damp = 36, 1, 26, 38, 700
+		initialBias, initialN        = 72, 128
+	)
+	adapt := func(delta, points int, first bool) int {
+		if first {
+			delta /= damp
+		} else {
+			delta /= 2
+		}
+		delta += delta / points
+		k := 0
+		for delta > ((base-tMin)*tMax)/2 {
+			delta /= base - tMin
+			k += base
+		}
+		return k + (base-tMin+1)*delta/(delta+skew)
+	}
+	digit := func(d int) byte {
+		if d < 26 {
+			return byte('a' + d)
+		}
+		return byte('0' + d - 26)
+	}
+
+	runes := []rune(s)
+	var out []byte
+	for _, c := range runes {
+		if c < 0x80 {
+			out = append(out, byte(c))
+		}
+	}
+	basic := len(out)
+	handled := basic
+	if basic > 0 {
+		out = append(out, '-')
+	}
+	n, delta, bias := initialN, 0, initialBias
+	for handled < len(runes) {
+		m := int(^uint(0) >> 1)
+		for _, c := range runes {
+			if int(c) >= n && int(c) < m {
+				m = int(c)
+			}
+		}
+		delta += (m - n) * (handled + 1)
+		n = m
+		for _, c := range runes {
+			if int(c) < n {
+				delta++
+			}
+			if int(c) != n {
+				continue
+			}
+			q := delta
+			for k := base; ; k += base {
+				t := k - bias
+				if t < tMin {
+					t = tMin
+				} else if t > tMax {
+					t = tMax
+				}
+				if q < t {
+					break
+				}
+				out = append(out, digit(t+(q-t)%(base-t)))
+				q = (q - t) / (base - t)
+			}
+			out = append(out, digit(q))
+			bias = adapt(delta, handled+1, handled == basic)
+			delta = 0
+			handled++
+		}
+		delta++
+		n++
+	}
+	return string(out)
+}
+
+// URLOptions drives the generation of a URL.
+type URLOptions struct {
+	// Scheme is the scheme of the URL. The zero value means "https".
+	Scheme string
+	// Host is the host name. The zero value means a random Hostname.
+	Host string
+	// UserInfo adds a user name, with a password half of the time.
+	UserInfo bool
+	// Port adds an explicit port.
+	Port bool
+	// PathSegments is the number of segments of the path. The zero value means an empty path.
+	PathSegments int
+	// QueryParams is the number of query parameters, a key being sometimes repeated.
+	QueryParams int
+	// Fragment adds a fragment.
+	Fragment bool
+	// Escaping puts characters which must be percent-encoded in the path, the query and the fragment,
+	// such as spaces, reserved characters and non-ASCII letters.
+	Escaping bool
+}
+
+var (
+	urlQueryKeys     = []string{"filter", "id", "lang", "limit", "offset", "page", "q", "ref", "sort", "utm_campaign", "utm_source"}
+	urlCommonPorts   = []int{3000, 8000, 8080, 8443, 8888, 9000}
+	urlSpecialValues = []string{"a b", "a&b", "a=b", "50%", "a/b", "a?b", "a#b", "a+b", "é", "日本", "\"quoted\""}
+)
+
+// URL returns a random URL following the supplied options.
+func (r *Rand) URL(opts URLOptions) *url.URL {
+	u := &url.URL{Scheme: strings.ToLower(opts.Scheme), Host: opts.Host}
+	if u.Scheme == "" {
+		u.Scheme = "https"
+	}
+	if u.Host == "" {
+		u.Host = r.Hostname()
+	}
+	if opts.UserInfo {
+		user := domainLabel(r.FirstName(RandomGender))
+		if r.Intn(2) == 0 {
+			u.User = url.UserPassword(user, r.urlWord(opts.Escaping)+r.Digits(4))
+		} else {
+			u.User = url.User(user)
+		}
+	}
+	if opts.Port {
+		port := r.Number(1024, 49152)
+		if r.Intn(2) == 0 {
+			port = urlCommonPorts[r.Intn(len(urlCommonPorts))]
+		}
+		u.Host = fmt.Sprintf("%s:%d", u.Host, port)
+	}
+	for i := 0; i < opts.PathSegments; i++ {
+		segment := r.urlWord(opts.Escaping)
+		if r.Intn(4) == 0 {
+			segment = strconv.Itoa(r.Number(1, 100000))
+		}
+		u.Path += "/" + segment
+		u.RawPath += "/" + url.PathEscape(segment)
+	}
+	// Like url.Parse, keep the raw path only when it differs from the default encoding, e.g. for an escaped slash.
+	if u.RawPath == (&url.URL{Path: u.Path}).EscapedPath() {
+		u.RawPath = ""
+	}
+	var query []string
+	for i := 0; i < opts.QueryParams; i++ {
+		key := r.StringFrom(urlQueryKeys)
+		if i > 0 && r.Intn(5) == 0 {
+			// Repeated keys are valid and often mishandled.
+			key = strings.SplitN(query[r.Intn(len(query))], "=", 2)[0]
+		}
+		query = append(query, key+"="+url.QueryEscape(r.urlWord(opts.Escaping)))
+	}
+	u.RawQuery = strings.Join(query, "&")
+	if opts.Fragment {
+		u.Fragment = r.urlWord(opts.Escaping)
+	}
+	return u
+}
+
+// urlWord returns a lower case word, with a character needing percent-encoding when escaping is requested.
+func (r *Rand) urlWord(escaping bool) string {
+	word := domainLabel(r.Noun())
+	if escaping {
+		word += r.StringFrom(urlSpecialValues)
+	}
+	return word
+}
@@ -0,0 +1,121 @@
+package randomdata
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainName(t *testing.T) {
+	r := FromSeed(1234)
+	for i := 0; i < 1000; i++ {
+		domain := r.DomainName()
+		assert.True(t, isHostname(domain), "invalid domain name %q", domain)
+		assert.True(t, hasTopLevelDomain(domain), "unknown top-level domain in %q", domain)
+		assert.LessOrEqual(t, strings.Count(domain, "."), 2, domain)
+	}
+}
+
+func TestHostname(t *testing.T) {
+	r := FromSeed(1234)
+	for i := 0; i < 1000; i++ {
+		host := r.Hostname()
+		assert.True(t, isHostname(host), "invalid host name %q", host)
+		assert.True(t, hasTopLevelDomain(host), "unknown top-level domain in %q", host)
+		assert.GreaterOrEqual(t, strings.Count(host, "."), 2, host)
+	}
+}
+
+func TestHost(t *testing.T) {
+	r := FromSeed(1234)
+	host := r.Host(HostOptions{Subdomains: 4, TLD: ".co.uk"})
+	assert.Len(t, strings.Split(host, "."), 7, host)
+	assert.True(t, strings.HasSuffix(host, ".co.uk"), host)
+
+	host = r.Host(HostOptions{Subdomains: 200})
+	assert.True(t, isHostname(host), "invalid host name %q", host)
+
+	for i := 0; i < 200; i++ {
+		host := r.Host(HostOptions{IDN: true})
+		assert.True(t, isHostname(host), "invalid host name %q", host)
+		assert.Contains(t, host, "xn--")
+
+		unicode := r.Host(HostOptions{IDN: true, Unicode: true})
+		assert.False(t, isHostname(unicode), "%q is ASCII", unicode)
+		labels := strings.Split(unicode, ".")
+		for i, label := range labels {
+			labels[i] = idnaLabel(label)
+		}
+		assert.True(t, isHostname(strings.Join(labels, ".")), "invalid host name %q", unicode)
+	}
+}
+
+func TestPunycode(t *testing.T) {
+	// Vectors from RFC 3492 section 7.1 and registered domain names.
+	for unicode, ascii := range map[string]string{
+		"bücher":    "xn--bcher-kva",
+		"münchen":   "xn--mnchen-3ya",
+		"рф":        "xn--p1ai",
+		"中国":        "xn--fiqs8s",
+		"한국":        "xn--3e0b707e",
+		"ελ":        "xn--qxam",
+		"онлайн":    "xn--80asehdb",
+		"他们为什么不说中文": "xn--ihqwcrb4cv8a8dqg056pqjye",
+		"bluefox":   "bluefox",
+	} {
+		assert.Equal(t, ascii, idnaLabel(unicode), unicode)
+	}
+}
+
+func TestIsHostname(t *testing.T) {
+	for _, host := range []string{"example.com", "a.b.c.d.example.co.uk", "xn--bcher-kva.de", "3com.com", "localhost", "example.com."} {
+		assert.True(t, isHostname(host), host)
+	}
+	for _, host := range []string{"", "-example.com", "example-.com", "exa_mple.com", "example..com", "192.168.0.1",
+		"bücher.de", strings.Repeat("a", 64) + ".com", strings.Repeat("abcdefghi.", 26) + "com"} {
+		assert.False(t, isHostname(host), host)
+	}
+}
+
+func TestURL(t *testing.T) {
+	r := FromSeed(1234)
+	u := r.URL(URLOptions{})
+	assert.Equal(t, "https", u.Scheme)
+	assert.True(t, isHostname(u.Host), u.Host)
+	assert.Empty(t, u.Path)
+	assert.Empty(t, u.RawQuery)
+	assert.Empty(t, u.Fragment)
+	assert.Nil(t, u.User)
+
+	for i := 0; i < 500; i++ {
+		opts := URLOptions{Scheme: "wss", UserInfo: true, Port: true, PathSegments: 3, QueryParams: 4, Fragment: true, Escaping: i%2 == 0}
+		u := r.URL(opts)
+		parsed, err := url.Parse(u.String())
+		if !assert.NoError(t, err, u.String()) {
+			continue
+		}
+		assert.Equal(t, u, parsed)
+		assert.Equal(t, "wss", parsed.Scheme)
+		assert.NotEmpty(t, parsed.User.Username())
+		assert.NotEmpty(t, parsed.Port())
+		assert.Len(t, strings.Split(parsed.EscapedPath(), "/"), 4, parsed.EscapedPath())
+		assert.Len(t, strings.Split(parsed.RawQuery, "&"), 4, parsed.RawQuery)
+		assert.NotEmpty(t, parsed.Fragment)
+	}
+
+	u = r.URL(URLOptions{Scheme: "http", Host: "localhost", PathSegments: 1})
+	assert.Equal(t, "http", u.Scheme)
+	assert.Equal(t, "localhost", u.Host)
+	assert.True(t, strings.HasPrefix(u.Path, "/"))
+}
+
+func hasTopLevelDomain(host string) bool {
+	for _, tld := range topLevelDomains {
+		if strings.HasSuffix(host, "."+tld) {
+			return true
+		}
+	}
+	return false
+}
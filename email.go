@@ -0,0 +1,223 @@
+package randomdata
+
+import (
+	"net/netip"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// EmailStyle decides the shape of an email address.
+type EmailStyle int
+
+const (
+	// PlainEmail is an ASCII address such as "john.doe42@example.com".
+	PlainEmail EmailStyle = iota
+	// PlusAddressedEmail carries a sub-address after a plus sign, e.g. "john.doe+newsletter@example.com".
+	PlusAddressedEmail
+	// SubdomainEmail has a domain below the registered one, e.g. "john.doe@mail.eu.example.com".
+	SubdomainEmail
+	// QuotedEmail has a quoted local part holding characters which are not allowed otherwise, e.g. "\"john doe\"@example.com".
+	QuotedEmail
+	// IPLiteralEmail has an IPv4 or IPv6 address literal as domain, e.g. "john.doe@[192.0.2.1]".
+	IPLiteralEmail
+	// InternationalEmail has a non-ASCII local part and possibly domain, which needs SMTPUTF8 (RFC 6531),
+	// e.g. "søren@bücher.de".
+	InternationalEmail
+	// LongestEmail has a 64 octets local part and the longest domain allowed by the 254 octets limit.
+	LongestEmail
+)
+
+// String returns the name of the email style.
+func (s EmailStyle) String() string {
+	switch s {
+	case PlainEmail:
+		return "plain"
+	case PlusAddressedEmail:
+		return "plus-addressed"
+	case SubdomainEmail:
+		return "subdomain"
+	case QuotedEmail:
+		return "quoted"
+	case IPLiteralEmail:
+		return "IP literal"
+	case InternationalEmail:
+		return "international"
+	case LongestEmail:
+		return "longest"
+	}
+	return "unknown"
+}
+
+const (
+	maxEmailLength     = 254 // RFC 5321 path limit of 256 octets, minus the angle brackets
+	maxLocalPartLength = 64
+)
+
+var (
+	emailTags = []string{"news", "newsletter", "shopping", "spam", "test", "work", "receipts", "github", "social"}
+	// emailQuotedInserts are put between the first and last names of quoted local parts.
+	emailQuotedInserts = []string{" ", "..", "@", ",", "(work)", "<>", "[]", ":", ";", `"`, `\`, " \"jr\" "}
+	emailUnicodeNames  = []string{
+		"josé", "zoë", "søren", "françois", "jürgen", "åsa", "łukasz", "ærlig", "ñoño",
+		"дмитрий", "александра", "μαρία", "γιώργος", "太郎", "さくら", "민준", "أحمد", "राहुल",
+	}
+	// emailFoldings are the letters which do not decompose into a base letter and diacritics.
+	emailFoldings = strings.NewReplacer("ß", "ss", "ø", "o", "æ", "ae", "œ", "oe", "ł", "l", "đ", "d", "ð", "d", "þ", "th", "ı", "i")
+)
+
+// EmailAddress returns a random email address of the supplied style.
+// Every style but InternationalEmail gives an ASCII address.
+func (r *Rand) EmailAddress(style EmailStyle) string {
+	first, last := r.FirstName(RandomGender), r.LastName()
+	if style == PlainEmail {
+		return r.createEmail(first, last)
+	}
+	local := emailAtom(first) + "." + emailAtom(last)
+	domain := r.StringFrom(jsonData.Domains)
+	switch style {
+	case PlusAddressedEmail:
+		local += "+" + r.StringFrom(emailTags)
+	case SubdomainEmail:
+		domain = r.StringFrom(subdomainLabels) + "." + domain
+		if r.Intn(2) == 0 {
+			domain = r.StringFrom(subdomainLabels) + "." + domain
+		}
+	case QuotedEmail:
+		local = quoteLocalPart(strings.ToLower(first) + r.StringFrom(emailQuotedInserts) + strings.ToLower(last))
+	case IPLiteralEmail:
+		if r.Intn(2) == 0 {
+			domain = "[" + r.IPv4(DocumentationAddress).String() + "]"
+		} else {
+			domain = "[IPv6:" + r.IPv6(DocumentationAddress).String() + "]"
+		}
+	case InternationalEmail:
+		local = r.StringFrom(emailUnicodeNames)
+		if r.Intn(2) == 0 {
+			local += "." + r.StringFrom(emailUnicodeNames)
+		}
+		domain = r.Host(HostOptions{IDN: true, Unicode: true})
+	case LongestEmail:
+		local = r.padLabel(local, maxLocalPartLength)
+		domain = r.longDomain(maxEmailLength-maxLocalPartLength-1, domain)
+	}
+	return local + "@" + domain
+}
+
+// padLabel fills s with random lower case letters and digits up to n octets.
+func (r *Rand) padLabel(s string, n int) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+	b := []byte(s)
+	for len(b) < n {
+		b = append(b, alphabet[r.Intn(len(alphabet))])
+	}
+	return string(b)
+}
+
+// longDomain returns a domain of n octets ending with suffix, padded with labels of at most 63 letters.
+func (r *Rand) longDomain(n int, suffix string) string {
+	domain := suffix
+	for len(domain) < n {
+		size := n - len(domain) - 1
+		if size > 63 {
+			size = 63
+			// Keep room for a last label of at least one octet.
+			if n-len(domain)-64 < 2 {
+				size = 61
+			}
+		}
+		domain = r.padLabel(string(rune('a'+r.Intn(26))), size) + "." + domain
+	}
+	return domain
+}
+
+// quoteLocalPart turns s into a quoted string, escaping the quotes and backslashes.
+func quoteLocalPart(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// emailAtom turns a name into a lower case ASCII dot-atom, dropping diacritics and the characters it can't hold.
+func emailAtom(name string) string {
+	folded, _, _ := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), strings.ToLower(name))
+	folded = emailFoldings.Replace(folded)
+	atom := strings.Map(func(c rune) rune {
+		if c > unicode.MaxASCII || !isAtext(c) {
+			return -1
+		}
+		return c
+	}, folded)
+	return strings.Trim(atom, ".")
+}
+
+// isAtext tells whether c can appear unquoted in a local part (RFC 5322 atext), non-ASCII letters being allowed
+// by SMTPUTF8 (RFC 6531).
+func isAtext(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", c) || c > unicode.MaxASCII && unicode.IsPrint(c)
+}
+
+// IsValidEmail tells whether address is a valid email address as used by SMTP (RFC 5321 and RFC 5322 addr-spec):
+// a dot-atom or quoted local part of at most 64 octets, then a host name or an address literal,
+// for at most 254 octets. Internationalized addresses (RFC 6531) are accepted.
+// Comments, folding white space and the obsolete syntax of RFC 5322 are rejected.
+func IsValidEmail(address string) bool {
+	if len(address) > maxEmailLength || !utf8.ValidString(address) {
+		return false
+	}
+	local, domain, ok := splitEmail(address)
+	if !ok || len(local) > maxLocalPartLength {
+		return false
+	}
+	if strings.HasPrefix(domain, "[") && strings.HasSuffix(domain, "]") {
+		literal := domain[1 : len(domain)-1]
+		if strings.HasPrefix(literal, "IPv6:") {
+			addr, err := netip.ParseAddr(literal[len("IPv6:"):])
+			return err == nil && addr.Is6() && addr.Zone() == ""
+		}
+		addr, err := netip.ParseAddr(literal)
+		return err == nil && addr.Is4()
+	}
+	labels := strings.Split(domain, ".")
+	for i, label := range labels {
+		labels[i] = idnaLabel(label)
+	}
+	return !strings.HasSuffix(domain, ".") && isHostname(strings.Join(labels, "."))
+}
+
+// splitEmail splits an address into its local part and domain, checking the syntax of the local part.
+func splitEmail(address string) (local, domain string, ok bool) {
+	if strings.HasPrefix(address, `"`) {
+		for i := 1; i < len(address); i++ {
+			switch c := address[i]; {
+			case c == '\\':
+				i++
+				if i == len(address) || address[i] < ' ' || address[i] == 0x7f {
+					return "", "", false
+				}
+			case c == '"':
+				if i+1 == len(address) || address[i+1] != '@' {
+					return "", "", false
+				}
+				return address[:i+1], address[i+2:], true
+			case c < ' ' || c == 0x7f:
+				return "", "", false
+			}
+		}
+		return "", "", false
+	}
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return "", "", false
+	}
+	local, domain = address[:at], address[at+1:]
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" || strings.IndexFunc(atom, func(c rune) bool { return !isAtext(c) }) >= 0 {
+			return "", "", false
+		}
+	}
+	return local, domain, true
+}
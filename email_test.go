@@ -0,0 +1,119 @@
+package randomdata
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailAddress(t *testing.T) {
+	r := FromSeed(1234)
+	styles := []EmailStyle{PlainEmail, PlusAddressedEmail, SubdomainEmail, QuotedEmail, IPLiteralEmail, InternationalEmail, LongestEmail}
+	for _, style := range styles {
+		for i := 0; i < 200; i++ {
+			address := r.EmailAddress(style)
+			assert.True(t, IsValidEmail(address), "invalid %v email %q", style, address)
+			assert.Equal(t, style != InternationalEmail, isASCII(address), "%v email %q", style, address)
+			at := strings.LastIndex(address, "@")
+			local, domain := address[:at], address[at+1:]
+			switch style {
+			case PlusAddressedEmail:
+				assert.Contains(t, local, "+")
+			case SubdomainEmail:
+				assert.GreaterOrEqual(t, strings.Count(domain, "."), 2, address)
+			case QuotedEmail:
+				assert.True(t, strings.HasPrefix(local, `"`) && strings.HasSuffix(local, `"`), address)
+			case IPLiteralEmail:
+				assert.True(t, strings.HasPrefix(domain, "[") && strings.HasSuffix(domain, "]"), address)
+			case LongestEmail:
+				assert.Len(t, local, 64)
+				assert.Len(t, address, 254)
+			}
+		}
+	}
+}
+
+func TestEmail(t *testing.T) {
+	assert.Equal(t, FromSeed(1234).Email(), FromSeed(1234).EmailAddress(PlainEmail))
+	r := FromSeed(1234)
+	for i := 0; i < 100; i++ {
+		email := r.Email()
+		assert.True(t, IsValidEmail(email), "invalid email %q", email)
+	}
+}
+
+func TestEmailAtom(t *testing.T) {
+	assert.Equal(t, "jose", emailAtom("José"))
+	assert.Equal(t, "soren", emailAtom("Søren"))
+	assert.Equal(t, "strasser", emailAtom("Straßer"))
+	assert.Equal(t, "o'brien", emailAtom("O'Brien"))
+	assert.Equal(t, "maryann", emailAtom("Mary Ann"))
+	assert.Equal(t, "vandenberg", emailAtom("van den Berg"))
+}
+
+func TestIsValidEmail(t *testing.T) {
+	valid := []string{
+		"simple@example.com",
+		"very.common@example.com",
+		"x@example.com",
+		"long.email-address-with-hyphens@and.subdomains.example.com",
+		"user.name+tag+sorting@example.com",
+		"admin@localhost",
+		"name/surname@example.com",
+		"!#$%&'*+-/=?^_`{|}~@example.org",
+		`" "@example.org`,
+		`"john..doe"@example.org`,
+		`"very.(),:;<>[]\".VERY.\"very@\\ \"very\".unusual"@strange.example.com`,
+		"postmaster@[123.123.123.123]",
+		"postmaster@[IPv6:2001:0db8:85a3:0000:0000:8a2e:0370:7334]",
+		"I❤️CHOCOLATE@example.com",
+		"用户@例子.广告",
+		"δοκιμή@παράδειγμα.δοκιμή",
+		"jürgen@bücher.de",
+		strings.Repeat("a", 64) + "@example.com",
+	}
+	for _, address := range valid {
+		assert.True(t, IsValidEmail(address), "%q should be valid", address)
+	}
+	invalid := []string{
+		"",
+		"plainaddress",
+		"@example.com",
+		"john@",
+		"abc.example.com",
+		"a@b@c@example.com",
+		`a"b(c)d,e:f;g<h>i[j\k]l@example.com`,
+		`just"not"right@example.com`,
+		`this is"not\allowed@example.com`,
+		`this\ still\"not\\allowed@example.com`,
+		".john@example.com",
+		"john.@example.com",
+		"john..doe@example.com",
+		"john@-example.com",
+		"john@example..com",
+		"john@example.com.",
+		"john@exa_mple.com",
+		"john@[300.1.1.1]",
+		"john@[2001:db8::1]",
+		"john@[IPv6:192.0.2.1]",
+		`"unterminated@example.com`,
+		`"quoted"john@example.com`,
+		"john@192.168.0.1",
+		"john doe@example.com",
+		"john(comment)@example.com",
+		strings.Repeat("a", 65) + "@example.com",
+		"john@" + strings.Repeat("a", 64) + ".com",
+		strings.Repeat("a", 64) + "@" + strings.Repeat(strings.Repeat("b", 60)+".", 3) + strings.Repeat("c", 10) + ".com",
+		"john@example.com\x00",
+		string([]byte{0xff}) + "@example.com",
+	}
+	for _, address := range invalid {
+		assert.False(t, IsValidEmail(address), "%q should be invalid", address)
+	}
+}
+
+func isASCII(s string) bool {
+	return utf8.RuneCountInString(s) == len(s)
+}
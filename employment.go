@@ -0,0 +1,323 @@
+package randomdata
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Company contains the data related to a fictitious company.
+type Company struct {
+	Name        string `json:"name"`        // includes the legal suffix, e.g. "Harris Logistics GmbH"
+	LegalSuffix string `json:"legalSuffix"` // e.g. "GmbH", "Ltd", "Inc."
+	Industry    string `json:"industry"`
+	Domain      string `json:"domain"`  // company email and web domain
+	TaxID       string `json:"taxId"`   // VAT or tax identification number
+	Country     string `json:"country"` // 2-letter country code
+}
+
+type companyFormat struct {
+	legalSuffixes []string
+	tld           string
+	taxID         func(r *Rand, legalSuffix string) string
+}
+
+// Tax identifiers obtained from:
+// * https://www.irs.gov/businesses/small-businesses-self-employed/how-eins-are-assigned-and-valid-ein-prefixes (US)
+// * https://ec.europa.eu/taxation_customs/vies (EU VAT numbers)
+// * https://abr.business.gov.au/Help/AbnFormat (AU)
+var companyFormats = map[string]companyFormat{
+	"US": {[]string{"Inc.", "LLC", "Corp."}, "com", (*Rand).ein},
+	"GB": {[]string{"Ltd", "PLC", "LLP"}, "co.uk", (*Rand).vatGB},
+	"DE": {[]string{"GmbH", "AG", "KG"}, "de", (*Rand).vatDE},
+	"FR": {[]string{"SA", "SARL", "SAS"}, "fr", (*Rand).vatFR},
+	"NL": {[]string{"B.V.", "N.V."}, "nl", (*Rand).vatNL},
+	"IT": {[]string{"S.r.l.", "S.p.A."}, "it", (*Rand).vatIT},
+	"ES": {[]string{"S.L.", "S.A."}, "es", (*Rand).cif},
+	"SE": {[]string{"AB"}, "se", (*Rand).vatSE},
+	"BR": {[]string{"Ltda.", "S.A."}, "com.br", (*Rand).cnpj},
+	"AU": {[]string{"Pty Ltd", "Ltd"}, "com.au", (*Rand).abn},
+}
+
+// Company returns a random company from one of the supported countries.
+func (r *Rand) Company() Company {
+	countries := make([]string, 0, len(companyFormats))
+	for country := range companyFormats {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return r.CompanyForCountry(r.StringFrom(countries))
+}
+
+// CompanyForCountry returns a random company registered in the supplied 2-letter country code,
+// with a legal suffix, domain and tax identifier typical to that country.
+// If the country is not supported the company has a generic suffix and domain and no tax identifier.
+func (r *Rand) CompanyForCountry(countrycode string) Company {
+	countrycode = strings.ToUpper(countrycode)
+	format, ok := companyFormats[countrycode]
+	if !ok {
+		format = companyFormat{legalSuffixes: []string{"Ltd"}, tld: "com"}
+	}
+	company := Company{
+		LegalSuffix: r.StringFrom(format.legalSuffixes),
+		Industry:    r.StringFrom(jsonData.Industries),
+		Country:     countrycode,
+	}
+	var base string
+	switch r.Intn(4) {
+	case 0:
+		base = r.LastName() + " & " + r.LastName()
+	case 1:
+		base = r.LastName() + " " + r.StringFrom(jsonData.CompanyWords)
+	case 2:
+		base = uppercaseFirstLetter(r.Adjective()) + " " + uppercaseFirstLetter(r.Noun()) + " " + r.StringFrom(jsonData.CompanyWords)
+	default:
+		base = r.SillyName()
+	}
+	company.Name = base + " " + company.LegalSuffix
+	company.Domain = domainLabel(base) + "." + format.tld
+	if format.taxID != nil {
+		company.TaxID = format.taxID(r, company.LegalSuffix)
+	}
+	return company
+}
+
+// domainLabel turns a company name into a lower case domain label.
+func domainLabel(name string) string {
+	return strings.Map(func(c rune) rune {
+		if c > unicode.MaxASCII || !(unicode.IsLetter(c) || unicode.IsDigit(c)) {
+			return -1
+		}
+		return unicode.ToLower(c)
+	}, name)
+}
+
+// US Employer Identification Number with a valid campus prefix.
+var einPrefixes = []int{
+	1, 2, 3, 4, 5, 6, 10, 11, 12, 13, 14, 15, 16, 20, 21, 22, 23, 24, 25, 26, 27,
+	30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48,
+	50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68,
+	71, 72, 73, 74, 75, 76, 77, 80, 81, 82, 83, 84, 85, 86, 87, 88, 90, 91, 92, 93, 94, 95, 98, 99,
+}
+
+func (r *Rand) ein(string) string {
+	return fmt.Sprintf("%02d-%s", einPrefixes[r.Intn(len(einPrefixes))], r.Digits(7))
+}
+
+// GB VAT number: 7 digits weighted 8 to 2 followed by 2 check digits making the total divisible by 97.
+func (r *Rand) vatGB(string) string {
+	number := strconv.Itoa(r.Number(1, 10)) + r.Digits(6)
+	sum := 0
+	for i := 0; i < 7; i++ {
+		sum += (8 - i) * int(number[i]-'0')
+	}
+	return fmt.Sprintf("GB%s%02d", number, (97-sum%97)%97)
+}
+
+// DE Umsatzsteuer-Identifikationsnummer: 8 digits and an ISO 7064 MOD 11,10 check digit.
+func (r *Rand) vatDE(string) string {
+	number := strconv.Itoa(r.Number(1, 10)) + r.Digits(7)
+	return "DE" + number + strconv.Itoa(iso7064Mod1110(number))
+}
+
+// FR numéro de TVA: a 2-digit key followed by the SIREN, itself ending with a Luhn check digit.
+func (r *Rand) vatFR(string) string {
+	siren := strconv.Itoa(r.Number(1, 10)) + r.Digits(7)
+	siren += strconv.Itoa(luhnCheckDigit(siren))
+	n, _ := strconv.Atoi(siren)
+	return fmt.Sprintf("FR%02d%s", (12+3*(n%97))%97, siren)
+}
+
+// NL btw-nummer: 9 digits using the same 11-check as the BSN, followed by a branch suffix.
+func (r *Rand) vatNL(string) string {
+	return "NL" + r.bsn() + "B01"
+}
+
+// IT partita IVA: 7-digit company number, 3-digit province office and a Luhn check digit.
+func (r *Rand) vatIT(string) string {
+	number := r.Digits(7) + fmt.Sprintf("%03d", r.Number(1, 101))
+	return "IT" + number + strconv.Itoa(luhnCheckDigit(number))
+}
+
+// ES CIF: an entity letter (A for S.A., B for S.L.), 7 digits and a check digit.
+func (r *Rand) cif(legalSuffix string) string {
+	letter := "B"
+	if legalSuffix == "S.A." {
+		letter = "A"
+	}
+	number := r.Digits(7)
+	sum := 0
+	for i := 0; i < 7; i++ {
+		d := int(number[i] - '0')
+		if i%2 == 0 {
+			d *= 2
+			d = d/10 + d%10
+		}
+		sum += d
+	}
+	return letter + number + strconv.Itoa((10-sum%10)%10)
+}
+
+// SE momsregistreringsnummer: the organisationsnummer of an aktiebolag with a Luhn check digit, followed by 01.
+func (r *Rand) vatSE(string) string {
+	number := "55" + strconv.Itoa(r.Number(6, 10)) + r.Digits(6)
+	return "SE" + number + strconv.Itoa(luhnCheckDigit(number)) + "01"
+}
+
+// BR CNPJ: 8-digit base, 4-digit branch and two mod 11 check digits.
+func (r *Rand) cnpj(string) string {
+	number := r.Digits(8) + "0001"
+	number += strconv.Itoa(cnpjCheckDigit(number))
+	number += strconv.Itoa(cnpjCheckDigit(number))
+	return fmt.Sprintf("%s.%s.%s/%s-%s", number[:2], number[2:5], number[5:8], number[8:12], number[12:])
+}
+
+func cnpjCheckDigit(digits string) int {
+	sum := 0
+	weight := len(digits) - 7
+	for i := 0; i < len(digits); i++ {
+		sum += int(digits[i]-'0') * weight
+		weight--
+		if weight < 2 {
+			weight = 9
+		}
+	}
+	if rest := sum % 11; rest >= 2 {
+		return 11 - rest
+	}
+	return 0
+}
+
+// AU Australian Business Number: 11 digits whose weighted sum, after subtracting 1 from the first digit, is divisible by 89.
+var abnWeights = []int{10, 1, 3, 5, 7, 9, 11, 13, 15, 17, 19}
+
+func (r *Rand) abn(string) string {
+	for {
+		number := strconv.Itoa(r.Number(1, 10)) + r.Digits(10)
+		sum := 0
+		for i := 0; i < 11; i++ {
+			d := int(number[i] - '0')
+			if i == 0 {
+				d--
+			}
+			sum += d * abnWeights[i]
+		}
+		if sum%89 == 0 {
+			return number[:2] + " " + number[2:5] + " " + number[5:8] + " " + number[8:]
+		}
+	}
+}
+
+// Seniority is the level of a position in a company.
+type Seniority int
+
+const (
+	Intern Seniority = iota
+	Junior
+	MidLevel
+	Senior
+	Lead
+	Manager
+	Director
+	VicePresident
+	Executive
+)
+
+var seniorityNames = []string{"intern", "junior", "mid-level", "senior", "lead", "manager", "director", "vice president", "executive"}
+
+// String returns the lower case name of the seniority level.
+func (s Seniority) String() string {
+	if s < Intern || s > Executive {
+		return "unknown"
+	}
+	return seniorityNames[s]
+}
+
+type department struct {
+	name      string
+	roles     []string
+	executive string
+}
+
+var departments = []department{
+	{"Engineering", []string{"Software Engineer", "Site Reliability Engineer", "QA Engineer", "Data Engineer", "Frontend Developer", "Backend Developer"}, "Chief Technology Officer"},
+	{"Product", []string{"Product Manager", "Product Owner", "Business Analyst"}, "Chief Product Officer"},
+	{"Design", []string{"UX Designer", "UI Designer", "Graphic Designer", "UX Researcher"}, "Chief Design Officer"},
+	{"Sales", []string{"Account Executive", "Sales Representative", "Business Development Representative", "Account Manager"}, "Chief Revenue Officer"},
+	{"Marketing", []string{"Marketing Specialist", "Content Strategist", "SEO Specialist", "Copywriter"}, "Chief Marketing Officer"},
+	{"Finance", []string{"Accountant", "Financial Analyst", "Controller", "Payroll Specialist"}, "Chief Financial Officer"},
+	{"Human Resources", []string{"Recruiter", "HR Generalist", "HR Business Partner", "Talent Acquisition Specialist"}, "Chief People Officer"},
+	{"Legal", []string{"Legal Counsel", "Paralegal", "Compliance Officer"}, "General Counsel"},
+	{"Operations", []string{"Operations Analyst", "Logistics Coordinator", "Supply Chain Analyst"}, "Chief Operating Officer"},
+	{"Customer Support", []string{"Support Specialist", "Customer Success Manager", "Technical Support Engineer"}, "Chief Customer Officer"},
+	{"IT", []string{"Systems Administrator", "Network Engineer", "IT Support Technician", "Security Analyst"}, "Chief Information Officer"},
+	{"Research and Development", []string{"Research Scientist", "Research Engineer", "Lab Technician"}, "Chief Scientific Officer"},
+}
+
+// Department returns the name of a random company department.
+func (r *Rand) Department() string {
+	return departments[r.Intn(len(departments))].name
+}
+
+// Seniority returns a random seniority level, junior to senior positions being the most frequent.
+func (r *Rand) Seniority() Seniority {
+	weights := []int{5, 20, 30, 20, 10, 8, 4, 2, 1}
+	n := r.Intn(100)
+	for i, w := range weights {
+		if n < w {
+			return Seniority(i)
+		}
+		n -= w
+	}
+	return MidLevel
+}
+
+// JobTitle returns a random job title, e.g. "Senior Software Engineer" or "Director of Marketing".
+func (r *Rand) JobTitle() string {
+	return r.JobTitleFor(r.Department(), r.Seniority())
+}
+
+// JobTitleFor returns a random job title in the supplied department at the supplied seniority level.
+// If the department is unknown a random one is used.
+func (r *Rand) JobTitleFor(departmentName string, seniority Seniority) string {
+	dep, ok := findDepartment(departmentName)
+	if !ok {
+		dep = departments[r.Intn(len(departments))]
+	}
+	role := r.StringFrom(dep.roles)
+	switch seniority {
+	case Intern:
+		return role + " Intern"
+	case Junior:
+		return "Junior " + role
+	case Senior:
+		return "Senior " + role
+	case Lead:
+		return "Lead " + role
+	case Manager:
+		return dep.name + " Manager"
+	case Director:
+		return "Director of " + dep.name
+	case VicePresident:
+		return "VP of " + dep.name
+	case Executive:
+		return dep.executive
+	}
+	return role
+}
+
+func findDepartment(name string) (department, bool) {
+	for _, d := range departments {
+		if strings.EqualFold(d.name, name) {
+			return d, true
+		}
+	}
+	return department{}, false
+}
+
+// workEmail returns a work email address at the supplied domain.
+func workEmail(firstName, lastName, domain string) string {
+	return emailAtom(firstName) + "." + emailAtom(lastName) + "@" + domain
+}
@@ -0,0 +1,119 @@
+package randomdata
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var companyTaxIDFormats = map[string]*regexp.Regexp{
+	"US": regexp.MustCompile(`^\d{2}-\d{7}$`),
+	"GB": regexp.MustCompile(`^GB\d{9}$`),
+	"DE": regexp.MustCompile(`^DE[1-9]\d{8}$`),
+	"FR": regexp.MustCompile(`^FR\d{11}$`),
+	"NL": regexp.MustCompile(`^NL\d{9}B01$`),
+	"IT": regexp.MustCompile(`^IT\d{11}$`),
+	"ES": regexp.MustCompile(`^[AB]\d{8}$`),
+	"SE": regexp.MustCompile(`^SE55\d{8}01$`),
+	"BR": regexp.MustCompile(`^\d{2}\.\d{3}\.\d{3}/0001-\d{2}$`),
+	"AU": regexp.MustCompile(`^\d{2} \d{3} \d{3} \d{3}$`),
+}
+
+func TestCompany(t *testing.T) {
+	r := FromSeed(1234)
+
+	for country, format := range companyTaxIDFormats {
+		for i := 0; i < 100; i++ {
+			c := r.CompanyForCountry(country)
+			assert.Equal(t, country, c.Country)
+			assert.Contains(t, companyFormats[country].legalSuffixes, c.LegalSuffix)
+			assert.True(t, strings.HasSuffix(c.Name, " "+c.LegalSuffix), "company name %q should end with its legal suffix", c.Name)
+			assert.Contains(t, jsonData.Industries, c.Industry)
+			assert.True(t, strings.HasSuffix(c.Domain, "."+companyFormats[country].tld), "invalid domain %q for country %q", c.Domain, country)
+			assert.Regexp(t, `^[a-z0-9]+\.[a-z.]+$`, c.Domain)
+			assert.Regexp(t, format, c.TaxID, "invalid tax id for country %q", country)
+		}
+	}
+
+	c := r.Company()
+	assert.Contains(t, companyFormats, c.Country)
+	assert.NotEmpty(t, c.TaxID)
+
+	c = r.CompanyForCountry("bogus")
+	assert.NotEmpty(t, c.Name)
+	assert.NotEmpty(t, c.Domain)
+	assert.Empty(t, c.TaxID, "did not return empty tax id for unknown country")
+}
+
+func TestCompanyTaxIDCheckDigits(t *testing.T) {
+	r := FromSeed(1234)
+	for i := 0; i < 100; i++ {
+		de := r.vatDE("")
+		assert.Equal(t, strconv.Itoa(iso7064Mod1110(de[2:10])), de[10:], "invalid DE VAT number %s", de)
+
+		fr := r.vatFR("")
+		siren, _ := strconv.Atoi(fr[4:])
+		key, _ := strconv.Atoi(fr[2:4])
+		assert.Equal(t, (12+3*(siren%97))%97, key, "invalid FR VAT key %s", fr)
+		assert.Equal(t, strconv.Itoa(luhnCheckDigit(fr[4:12])), fr[12:], "invalid FR SIREN %s", fr)
+
+		assert.True(t, validBSN(r.vatNL("")[2:11]), "invalid NL VAT number")
+
+		gb := r.vatGB("")
+		sum, _ := strconv.Atoi(gb[9:])
+		for j := 0; j < 7; j++ {
+			sum += (8 - j) * int(gb[2+j]-'0')
+		}
+		assert.Zero(t, sum%97, "invalid GB VAT number %s", gb)
+
+		br := stripSeparators(r.cnpj(""), "./-")
+		assert.Equal(t, strconv.Itoa(cnpjCheckDigit(br[:12])), br[12:13], "invalid CNPJ %s", br)
+		assert.Equal(t, strconv.Itoa(cnpjCheckDigit(br[:13])), br[13:], "invalid CNPJ %s", br)
+
+		au := stripSeparators(r.abn(""), " ")
+		sum = 0
+		for j := range abnWeights {
+			d := int(au[j] - '0')
+			if j == 0 {
+				d--
+			}
+			sum += d * abnWeights[j]
+		}
+		assert.Zero(t, sum%89, "invalid ABN %s", au)
+	}
+
+	// Known valid CNPJ.
+	assert.Equal(t, "11.222.333/0001-81", "11.222.333/0001-"+strconv.Itoa(cnpjCheckDigit("112223330001"))+strconv.Itoa(cnpjCheckDigit("1122233300018")))
+}
+
+func TestJobTitle(t *testing.T) {
+	r := FromSeed(1234)
+
+	names := []string{}
+	for _, d := range departments {
+		names = append(names, d.name)
+	}
+	assert.Contains(t, names, r.Department())
+	assert.NotEmpty(t, r.JobTitle())
+
+	for i := 0; i < 100; i++ {
+		s := r.Seniority()
+		assert.GreaterOrEqual(t, s, Intern)
+		assert.LessOrEqual(t, s, Executive)
+	}
+
+	assert.Contains(t, []string{"Senior Software Engineer", "Senior Site Reliability Engineer", "Senior QA Engineer",
+		"Senior Data Engineer", "Senior Frontend Developer", "Senior Backend Developer"}, r.JobTitleFor("engineering", Senior))
+	assert.Equal(t, "Director of Marketing", r.JobTitleFor("Marketing", Director))
+	assert.Equal(t, "Finance Manager", r.JobTitleFor("Finance", Manager))
+	assert.Equal(t, "Chief Financial Officer", r.JobTitleFor("Finance", Executive))
+	assert.True(t, strings.HasSuffix(r.JobTitleFor("Legal", Intern), " Intern"))
+	assert.NotEmpty(t, r.JobTitleFor("bogus", MidLevel))
+
+	assert.Equal(t, "mid-level", MidLevel.String())
+	assert.Equal(t, "vice president", VicePresident.String())
+	assert.Equal(t, "unknown", Seniority(42).String())
+}
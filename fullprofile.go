@@ -54,32 +54,29 @@ type Profile struct {
 		Thumbnail string `json:"thumbnail"`
 	} `json:"picture"`
 	Nat string `json:"nat"`
+
+	Employment struct {
+		Company    Company `json:"company"`
+		Department string  `json:"department"`
+		JobTitle   string  `json:"jobTitle"`
+		Seniority  string  `json:"seniority"`
+		Email      string  `json:"email"`
+	} `json:"employment"`
 }
 
 // GenerateProfile generates a full profile.
-func (r *Rand) GenerateProfile(gender int) *Profile {
+// RandomGender, as well as any unknown gender, picks between Male and Female.
+func (r *Rand) GenerateProfile(gender Gender) *Profile {
 	profile := &Profile{}
-	if gender == Male {
-		profile.Gender = "male"
-	} else if gender == Female {
-		profile.Gender = "female"
-	} else {
-		gender = r.Intn(2)
-		if gender == Male {
-			profile.Gender = "male"
-		} else {
-			profile.Gender = "female"
-		}
+	if gender != Male && gender != Female && gender != NonBinary {
+		gender = Gender(r.Intn(2))
 	}
+	profile.Gender = gender.String()
 	profile.Name.Title = r.Title(gender)
 	profile.Name.First = r.FirstName(gender)
 	profile.Name.Last = r.LastName()
 	profile.ID.Name = "SSN"
-	profile.ID.Value = fmt.Sprintf("%d-%d-%d",
-		r.Number(101, 999),
-		r.Number(01, 99),
-		r.Number(100, 9999),
-	)
+	profile.ID.Value = r.NationalID("US")
 
 	profile.Email = r.createEmail(profile.Name.First, profile.Name.Last)
 	profile.Cell = r.PhoneNumber()
@@ -88,12 +85,21 @@ func (r *Rand) GenerateProfile(gender int) *Profile {
 	profile.Registered = r.FullDate()
 	profile.Nat = "US"
 
-	profile.Location.City = r.City()
-	i, _ := strconv.Atoi(r.PostalCode("US"))
+	place := r.Place(profile.Nat)
+	profile.Location.City = place.City
+	i, _ := strconv.Atoi(place.PostalCode)
 	profile.Location.Postcode = i
-	profile.Location.State = r.State(2)
+	profile.Location.State = subdivisionName(place.Subdivision)
 	profile.Location.Street = r.StringNumber(1, "") + " " + r.Street()
 
+	department := r.Department()
+	seniority := r.Seniority()
+	profile.Employment.Company = r.CompanyForCountry(profile.Nat)
+	profile.Employment.Department = department
+	profile.Employment.JobTitle = r.JobTitleFor(department, seniority)
+	profile.Employment.Seniority = seniority.String()
+	profile.Employment.Email = workEmail(profile.Name.First, profile.Name.Last, profile.Employment.Company.Domain)
+
 	profile.Login.Username = r.SillyName()
 	pass := r.SillyName()
 	salt := r.RandStringRunes(16)
@@ -104,13 +110,29 @@ func (r *Rand) GenerateProfile(gender int) *Profile {
 	profile.Login.Sha256 = getSha256(pass + salt)
 
 	pic := r.Intn(35)
-	profile.Picture.Large = fmt.Sprintf("https://randomuser.me/api/portraits/%s/%d.jpg", portraitDirs[gender], pic)
-	profile.Picture.Medium = fmt.Sprintf("https://randomuser.me/api/portraits/med/%s/%d.jpg", portraitDirs[gender], pic)
-	profile.Picture.Thumbnail = fmt.Sprintf("https://randomuser.me/api/portraits/thumb/%s/%d.jpg", portraitDirs[gender], pic)
+	portraitDir := r.portraitDir(gender)
+	profile.Picture.Large = fmt.Sprintf("https://randomuser.me/api/portraits/%s/%d.jpg", portraitDir, pic)
+	profile.Picture.Medium = fmt.Sprintf("https://randomuser.me/api/portraits/med/%s/%d.jpg", portraitDir, pic)
+	profile.Picture.Thumbnail = fmt.Sprintf("https://randomuser.me/api/portraits/thumb/%s/%d.jpg", portraitDir, pic)
 
 	return profile
 }
 
+// GenerateProfileWithRatio generates a full profile whose gender is picked according to the supplied ratio.
+// A zero ratio means DefaultGenderRatio.
+func (r *Rand) GenerateProfileWithRatio(ratio GenderRatio) *Profile {
+	return r.GenerateProfile(r.PickGender(ratio))
+}
+
+// portraitDir returns the portrait directory for a gender.
+// There are no dedicated portraits for NonBinary, so one of the others is picked at random.
+func (r *Rand) portraitDir(gender Gender) string {
+	if gender != Male && gender != Female {
+		gender = Gender(r.Intn(2))
+	}
+	return portraitDirs[gender]
+}
+
 func getMD5Hash(text string) string {
 	hasher := md5.New()
 	hasher.Write([]byte(text))
@@ -1,6 +1,7 @@
 package randomdata
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,5 +25,25 @@ func Test_FullProfileGenerator(t *testing.T) {
 	assert.NotEmpty(t, profile.Login.Username, "profile Username failed to generate")
 	assert.NotEmpty(t, profile.Location.Street, "profile Street failed to generate")
 	assert.Equal(t, "SSN", profile.ID.Name, "profile ID Name to be SSN, but got %s\n", profile.ID.Name)
+	assert.True(t, ValidateNationalID("US", profile.ID.Value.(string)), "profile ID Value to be a valid SSN, but got %v\n", profile.ID.Value)
 	assert.NotEmpty(t, profile.Picture.Large, "profile Picture Large failed to generate", profile.Picture.Large)
+
+	assert.NotEmpty(t, profile.Location.City, "profile City failed to generate")
+	assert.NotEmpty(t, profile.Location.State, "profile State failed to generate")
+	assert.Positive(t, profile.Location.Postcode, "profile Postcode failed to generate")
+
+	assert.Equal(t, "US", profile.Employment.Company.Country)
+	assert.NotEmpty(t, profile.Employment.JobTitle, "profile JobTitle failed to generate")
+	assert.NotEmpty(t, profile.Employment.Department, "profile Department failed to generate")
+	assert.True(t, strings.HasSuffix(profile.Employment.Email, "@"+profile.Employment.Company.Domain),
+		"expected work email %q to use the company domain %q", profile.Employment.Email, profile.Employment.Company.Domain)
+
+	profile = r.GenerateProfile(NonBinary)
+	assert.Equal(t, "nonbinary", profile.Gender)
+	assert.Equal(t, "Mx", profile.Name.Title)
+	assert.Contains(t, jsonData.FirstNamesNeutral, profile.Name.First)
+	assert.NotEmpty(t, profile.Picture.Large, "profile Picture Large failed to generate", profile.Picture.Large)
+
+	profile = r.GenerateProfileWithRatio(GenderRatio{Female: 1})
+	assert.Equal(t, "female", profile.Gender)
 }
@@ -0,0 +1,370 @@
+package randomdata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Points can be encoded as geohashes only: H3 cell indexes are not supported, as they need the
+// icosahedron projection and base cell tables of the H3 library.
+
+// earthRadius is the mean radius of the Earth in meters.
+const earthRadius = 6371008.8
+
+// LatLng is a point on the Earth, in decimal degrees.
+type LatLng struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// BBox is an area delimited by two parallels and two meridians, in decimal degrees.
+// A box whose East is lower than its West crosses the antimeridian.
+type BBox struct {
+	South float64 `json:"south"`
+	West  float64 `json:"west"`
+	North float64 `json:"north"`
+	East  float64 `json:"east"`
+}
+
+// LatLng returns a random point uniformly distributed on the surface of the Earth.
+func (r *Rand) LatLng() LatLng {
+	return r.PointInBBox(BBox{South: -90, West: -180, North: 90, East: 180})
+}
+
+// PointInBBox returns a random point uniformly distributed over the surface of the supplied box.
+// Points are not denser near the poles, as they would be if the latitude was picked uniformly.
+func (r *Rand) PointInBBox(box BBox) LatLng {
+	east := box.East
+	if east < box.West {
+		east += 360
+	}
+	low, high := math.Sin(radians(box.South)), math.Sin(radians(box.North))
+	lat := degrees(math.Asin(low + r.Float64()*(high-low)))
+	return LatLng{Lat: lat, Lng: normalizeLng(box.West + r.Float64()*(east-box.West))}
+}
+
+// PointWithinRadius returns a random point uniformly distributed over the disk of the supplied radius,
+// in meters, around center.
+func (r *Rand) PointWithinRadius(center LatLng, meters float64) LatLng {
+	angle := math.Min(meters/earthRadius, math.Pi)
+	// The area of a spherical cap grows with sin²(d/2), so sqrt(u) gives points uniform by area.
+	distance := 2 * math.Asin(math.Sqrt(r.Float64())*math.Sin(angle/2))
+	return center.destination(2*math.Pi*r.Float64(), distance)
+}
+
+// ErrNoArea is returned by PointInPolygon when the polygon has no area to pick a point from.
+var ErrNoArea = errors.New("randomdata: polygon has no area")
+
+// PointInPolygon returns a random point uniformly distributed inside a GeoJSON Polygon or MultiPolygon.
+// The geometry may also be wrapped in a Feature or a FeatureCollection. Holes are honored.
+// Edges are straight lines in longitude and latitude, as in GeoJSON, and must not cross the antimeridian.
+func (r *Rand) PointInPolygon(geojson []byte) (LatLng, error) {
+	polygons, err := parsePolygons(geojson)
+	if err != nil {
+		return LatLng{}, err
+	}
+	return r.pointInPolygons(polygons)
+}
+
+// PointInCountry returns a random point inside a coarse outline of the supplied 2-letter country code.
+// The outlines contain the whole country, most of its islands and cities, but also some sea and
+// neighbouring land. Outlines exist for 11 countries, those of SupportedPlaceCountries: AU, BR, CA, DE,
+// ES, FR, GB, IT, NL, SE and the contiguous states of the US.
+// If the country is not supported it will return an empty LatLng.
+func (r *Rand) PointInCountry(countrycode string) LatLng {
+	outline, ok := countryOutlines[strings.ToUpper(countrycode)]
+	if !ok {
+		return LatLng{}
+	}
+	point, _ := r.pointInPolygons(outlinePolygons(outline))
+	return point
+}
+
+// CountryPolygon returns the outline used by PointInCountry as a GeoJSON MultiPolygon,
+// or nil if the country is not supported.
+func CountryPolygon(countrycode string) []byte {
+	outline, ok := countryOutlines[strings.ToUpper(countrycode)]
+	if !ok {
+		return nil
+	}
+	geometry, _ := json.Marshal(geoJSONGeometry{Type: "MultiPolygon", Coordinates: outlinePolygons(outline)})
+	return geometry
+}
+
+// LatLng returns the coordinates of the place.
+func (p Place) LatLng() LatLng {
+	return LatLng{Lat: p.Latitude, Lng: p.Longitude}
+}
+
+// DistanceTo returns the great-circle distance to q, in meters.
+func (p LatLng) DistanceTo(q LatLng) float64 {
+	dLat, dLng := radians(q.Lat-p.Lat), radians(q.Lng-p.Lng)
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(radians(p.Lat))*math.Cos(radians(q.Lat))*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// String returns the point as "lat,lng".
+func (p LatLng) String() string {
+	return fmt.Sprintf("%.6f,%.6f", p.Lat, p.Lng)
+}
+
+// Feature returns the point as a GeoJSON Feature with the supplied properties.
+func (p LatLng) Feature(properties map[string]interface{}) ([]byte, error) {
+	return json.Marshal(geoJSONFeature{
+		Type:       "Feature",
+		Geometry:   geoJSONGeometry{Type: "Point", Coordinates: [2]float64{p.Lng, p.Lat}},
+		Properties: properties,
+	})
+}
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Geohash returns the geohash of the point with the supplied number of characters, between 1 and 12.
+func (p LatLng) Geohash(precision int) string {
+	if precision < 1 {
+		precision = 1
+	} else if precision > 12 {
+		precision = 12
+	}
+	lat, lng := [2]float64{-90, 90}, [2]float64{-180, 180}
+	hash := make([]byte, precision)
+	even := true
+	for i := range hash {
+		index := 0
+		for bit := 0; bit < 5; bit++ {
+			interval, value := &lat, p.Lat
+			if even {
+				interval, value = &lng, p.Lng
+			}
+			mid := (interval[0] + interval[1]) / 2
+			index <<= 1
+			if value >= mid {
+				index |= 1
+				interval[0] = mid
+			} else {
+				interval[1] = mid
+			}
+			even = !even
+		}
+		hash[i] = geohashAlphabet[index]
+	}
+	return string(hash)
+}
+
+// DecodeGeohash returns the center of the cell of the supplied geohash.
+func DecodeGeohash(hash string) (LatLng, error) {
+	if hash == "" {
+		return LatLng{}, errors.New("randomdata: empty geohash")
+	}
+	lat, lng := [2]float64{-90, 90}, [2]float64{-180, 180}
+	even := true
+	for _, c := range strings.ToLower(hash) {
+		index := strings.IndexRune(geohashAlphabet, c)
+		if index < 0 {
+			return LatLng{}, fmt.Errorf("randomdata: invalid geohash character %q", c)
+		}
+		for bit := 4; bit >= 0; bit-- {
+			interval := &lat
+			if even {
+				interval = &lng
+			}
+			mid := (interval[0] + interval[1]) / 2
+			if index>>bit&1 == 1 {
+				interval[0] = mid
+			} else {
+				interval[1] = mid
+			}
+			even = !even
+		}
+	}
+	return LatLng{Lat: (lat[0] + lat[1]) / 2, Lng: (lng[0] + lng[1]) / 2}, nil
+}
+
+type geoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// geoJSONObject holds the members of any GeoJSON object PointInPolygon accepts.
+type geoJSONObject struct {
+	Type        string            `json:"type"`
+	Coordinates json.RawMessage   `json:"coordinates"`
+	Geometry    json.RawMessage   `json:"geometry"`
+	Features    []json.RawMessage `json:"features"`
+}
+
+// parsePolygons returns the polygons, as lists of [lng, lat] rings, of a GeoJSON object.
+func parsePolygons(data []byte) ([][][][2]float64, error) {
+	var object geoJSONObject
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("randomdata: invalid GeoJSON: %w", err)
+	}
+	switch object.Type {
+	case "Polygon":
+		var polygon [][][2]float64
+		if err := json.Unmarshal(object.Coordinates, &polygon); err != nil {
+			return nil, fmt.Errorf("randomdata: invalid Polygon coordinates: %w", err)
+		}
+		return [][][][2]float64{polygon}, nil
+	case "MultiPolygon":
+		var polygons [][][][2]float64
+		if err := json.Unmarshal(object.Coordinates, &polygons); err != nil {
+			return nil, fmt.Errorf("randomdata: invalid MultiPolygon coordinates: %w", err)
+		}
+		return polygons, nil
+	case "Feature":
+		return parsePolygons(object.Geometry)
+	case "FeatureCollection":
+		var polygons [][][][2]float64
+		for _, feature := range object.Features {
+			p, err := parsePolygons(feature)
+			if err != nil {
+				return nil, err
+			}
+			polygons = append(polygons, p...)
+		}
+		return polygons, nil
+	}
+	return nil, fmt.Errorf("randomdata: unsupported GeoJSON type %q", object.Type)
+}
+
+// maxPolygonAttempts bounds the rejection sampling of pointInPolygons.
+const maxPolygonAttempts = 10000
+
+// pointInPolygons picks a polygon with a probability proportional to the area of its bounding box,
+// then a point in that box until one falls inside the polygon. Both steps together are uniform by area.
+func (r *Rand) pointInPolygons(polygons [][][][2]float64) (LatLng, error) {
+	boxes := make([]BBox, 0, len(polygons))
+	weights := make([]float64, 0, len(polygons))
+	total := 0.0
+	for _, polygon := range polygons {
+		if len(polygon) == 0 || len(polygon[0]) < 3 {
+			continue
+		}
+		box := ringBBox(polygon[0])
+		weight := (box.East - box.West) * (math.Sin(radians(box.North)) - math.Sin(radians(box.South)))
+		boxes = append(boxes, box)
+		weights = append(weights, weight)
+		total += weight
+	}
+	if total <= 0 {
+		return LatLng{}, ErrNoArea
+	}
+	for attempt := 0; attempt < maxPolygonAttempts; attempt++ {
+		pick, i := r.Float64()*total, 0
+		for i < len(weights)-1 && pick >= weights[i] {
+			pick -= weights[i]
+			i++
+		}
+		point := r.PointInBBox(boxes[i])
+		if polygonContains(polygons[i], point) {
+			return point, nil
+		}
+	}
+	return LatLng{}, ErrNoArea
+}
+
+// polygonContains tells whether the point is inside the outer ring of the polygon and outside its holes.
+func polygonContains(polygon [][][2]float64, p LatLng) bool {
+	inside := false
+	for _, ring := range polygon {
+		for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+			a, b := ring[i], ring[j]
+			if (a[1] > p.Lat) != (b[1] > p.Lat) && p.Lng < (b[0]-a[0])*(p.Lat-a[1])/(b[1]-a[1])+a[0] {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+func ringBBox(ring [][2]float64) BBox {
+	box := BBox{South: ring[0][1], West: ring[0][0], North: ring[0][1], East: ring[0][0]}
+	for _, point := range ring[1:] {
+		box.West, box.East = math.Min(box.West, point[0]), math.Max(box.East, point[0])
+		box.South, box.North = math.Min(box.South, point[1]), math.Max(box.North, point[1])
+	}
+	return box
+}
+
+// outlinePolygons turns the rings of a country outline into GeoJSON MultiPolygon coordinates.
+func outlinePolygons(outline [][][2]float64) [][][][2]float64 {
+	polygons := make([][][][2]float64, len(outline))
+	for i, ring := range outline {
+		polygons[i] = [][][2]float64{ring}
+	}
+	return polygons
+}
+
+// destination returns the point reached from p after travelling the supplied angular distance,
+// in radians, along the supplied bearing, in radians clockwise from north.
+func (p LatLng) destination(bearing, distance float64) LatLng {
+	lat1, lng1 := radians(p.Lat), radians(p.Lng)
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(distance) + math.Cos(lat1)*math.Sin(distance)*math.Cos(bearing))
+	lng2 := lng1 + math.Atan2(math.Sin(bearing)*math.Sin(distance)*math.Cos(lat1), math.Cos(distance)-math.Sin(lat1)*math.Sin(lat2))
+	return LatLng{Lat: degrees(lat2), Lng: normalizeLng(degrees(lng2))}
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
+
+// normalizeLng brings a longitude back to [-180, 180).
+func normalizeLng(lng float64) float64 {
+	lng = math.Mod(lng+180, 360)
+	if lng < 0 {
+		lng += 360
+	}
+	return lng - 180
+}
+
+// countryOutlines are coarse outer rings, as [lng, lat], containing each country and its main islands.
+var countryOutlines = map[string][][][2]float64{
+	"AU": {
+		{{113, -22}, {114, -21.8}, {122, -17}, {129, -14.8}, {130.3, -11.2}, {136.9, -11}, {136.5, -15}, {140.8, -17.4}, {141.5, -10.7}, {145.3, -14.9}, {147.5, -19}, {149.5, -22}, {153.7, -25}, {153.7, -28.5}, {152.6, -32.5}, {150.2, -37.5}, {147, -38.3}, {148.4, -40.9}, {148.3, -43.3}, {146, -43.7}, {144.5, -40.7}, {140.8, -38.1}, {137.8, -36}, {135.8, -35.3}, {131, -31.5}, {123.5, -34}, {117.8, -35.2}, {114.9, -34.5}, {115, -31}, {113, -26}, {113, -22}},
+	},
+	"BR": {
+		{{-73.9, -7.4}, {-70, -4}, {-69.5, 1}, {-64, 4}, {-60, 5.3}, {-51.7, 4.4}, {-50, 1.8}, {-44, -2.2}, {-38, -3}, {-35, -5}, {-34.6, -7.5}, {-34.7, -8.5}, {-38.3, -13}, {-39, -17.7}, {-40.9, -22}, {-43, -23.1}, {-44.7, -23.5}, {-48.5, -26}, {-48.3, -28.5}, {-53.4, -33.8}, {-57.6, -30.2}, {-53.6, -26.2}, {-54.6, -25.6}, {-58.2, -20.1}, {-57.9, -16.3}, {-60.2, -13.5}, {-65.4, -9.8}, {-72.4, -9.7}, {-73.9, -7.4}},
+	},
+	"CA": {
+		{{-141, 60}, {-141, 69.7}, {-110, 78}, {-80, 83.2}, {-61, 82}, {-61, 66}, {-55.5, 52}, {-52.5, 47.7}, {-52.6, 46.6}, {-59.7, 45.9}, {-59.8, 43.9}, {-66, 43.4}, {-67.1, 45.1}, {-67.8, 47.1}, {-69.2, 47.5}, {-71.5, 45}, {-74.7, 45}, {-76.3, 43.9}, {-79, 43.4}, {-79.2, 42.8}, {-82.5, 42}, {-82.4, 45.3}, {-84.8, 46.8}, {-89.6, 48}, {-95.2, 49}, {-123, 49}, {-123.2, 48.2}, {-125.5, 48.3}, {-133, 54.6}, {-130, 55.8}, {-141, 60}},
+	},
+	"DE": {
+		{{6, 50.8}, {6.1, 51.9}, {7, 52.2}, {7, 53.3}, {8.5, 53.7}, {8.7, 55}, {9.9, 54.8}, {11, 54}, {13, 54.6}, {14.2, 53.9}, {14.4, 53.2}, {14.8, 50.9}, {12.1, 50.3}, {13.8, 48.8}, {13, 47.5}, {10.2, 47.3}, {7.6, 47.6}, {8.2, 49}, {6.4, 49.2}, {6, 50.8}},
+	},
+	"ES": {
+		{{-9.3, 43.2}, {-7.7, 43.8}, {-1.8, 43.4}, {0.7, 42.9}, {3.3, 42.4}, {3.2, 41.9}, {0.6, 40.6}, {-0.3, 39.4}, {0.2, 38.7}, {-0.7, 37.6}, {-2.2, 36.7}, {-5.6, 36}, {-6.4, 36.8}, {-7.4, 37.2}, {-7, 38}, {-7.3, 39.5}, {-6.9, 41.9}, {-8.2, 42}, {-8.9, 41.9}, {-9.3, 43.2}},
+		{{1.1, 38.6}, {4.4, 39.8}, {4.4, 40.2}, {2.3, 40}, {1.1, 39.2}, {1.1, 38.6}},
+		{{-18.3, 27.5}, {-13.3, 27.5}, {-13.3, 29.5}, {-18.3, 29.5}, {-18.3, 27.5}},
+	},
+	"FR": {
+		{{-4.8, 48.4}, {-1.9, 48.7}, {-1.3, 49.7}, {1.5, 50.1}, {2.5, 51.1}, {4.2, 49.9}, {5.8, 49.5}, {8.2, 49}, {7.6, 47.6}, {6, 46.2}, {7, 45.9}, {7.7, 44.2}, {7.6, 43.7}, {6.5, 43.1}, {3.2, 43.2}, {3.2, 42.4}, {-1.8, 43.3}, {-1.2, 46.1}, {-2.6, 47.2}, {-4.8, 48.4}},
+		{{8.5, 41.3}, {9.6, 41.3}, {9.6, 43.1}, {8.5, 43.1}, {8.5, 41.3}},
+	},
+	"GB": {
+		{{-5.8, 49.9}, {-4.2, 50.2}, {-1.2, 50.5}, {1.5, 50.8}, {1.8, 51.4}, {1.8, 52.9}, {0.3, 53.6}, {-1.3, 55}, {-1.5, 55.7}, {-1.7, 57.7}, {-3, 58.7}, {-5, 58.7}, {-6.3, 56.5}, {-5.6, 55.3}, {-6.2, 55.3}, {-7.3, 55.3}, {-8.2, 54.5}, {-7.1, 54}, {-6, 54}, {-4.6, 53.4}, {-5.4, 51.8}, {-3.4, 51.3}, {-5.8, 49.9}},
+	},
+	"IT": {
+		{{6.6, 45.1}, {7, 45.9}, {8.4, 46.4}, {9.5, 46.4}, {10.4, 46.9}, {12.1, 47.1}, {13.7, 46.5}, {13.9, 45.6}, {12.3, 45.2}, {12.4, 44.2}, {13.6, 43.5}, {14.9, 42}, {16.2, 41.9}, {18.6, 40.1}, {16.7, 38.4}, {15.9, 37.9}, {15.6, 40}, {14.1, 40.8}, {11.2, 42.4}, {10.1, 44}, {8.4, 44.2}, {7.5, 43.8}, {6.6, 45.1}},
+		{{12.3, 37.9}, {13.4, 38.3}, {15.7, 38.3}, {15.3, 36.6}, {12.3, 37.5}, {12.3, 37.9}},
+		{{8.1, 39}, {9.7, 39}, {9.9, 41.3}, {8.1, 41}, {8.1, 39}},
+	},
+	"NL": {
+		{{3.3, 51.4}, {4, 52}, {4.7, 53.1}, {6, 53.5}, {7.2, 53.3}, {7, 52.2}, {6.1, 51.9}, {6.2, 51.4}, {5.9, 50.75}, {5.6, 50.75}, {5.7, 51.2}, {4.2, 51.4}, {3.3, 51.4}},
+	},
+	"SE": {
+		{{11, 58.9}, {11.9, 57.5}, {12.6, 56.2}, {12.9, 55.3}, {14.4, 55.5}, {16.3, 56.6}, {16.6, 57.9}, {18.5, 59.3}, {19.3, 60}, {17.3, 61.3}, {17.9, 62.9}, {20.9, 63.6}, {21.5, 64.5}, {24.2, 65.8}, {23.7, 67.9}, {20.6, 69.1}, {18, 68}, {14.5, 65}, {12.1, 62}, {12.5, 60}, {11, 58.9}},
+	},
+	"US": {
+		{{-124.8, 48.4}, {-123.2, 48.2}, {-123, 49}, {-95.2, 49}, {-89.6, 48}, {-84.8, 46.8}, {-82.4, 45.3}, {-82.5, 42}, {-79.2, 42.8}, {-79, 43.4}, {-76.3, 43.9}, {-74.7, 45}, {-71.5, 45}, {-69.2, 47.5}, {-67.8, 47.1}, {-66.9, 44.8}, {-69.8, 43.6}, {-70, 41.6}, {-73.9, 40.3}, {-75, 38.6}, {-75.4, 35.2}, {-76.9, 34.5}, {-81, 31.5}, {-79.9, 26.8}, {-80, 25.3}, {-81.2, 25}, {-82.2, 26.5}, {-84.3, 29.9}, {-89, 30}, {-89.4, 28.9}, {-94, 29.4}, {-97.2, 25.9}, {-99.5, 27.5}, {-101.4, 29.8}, {-104.5, 29.5}, {-106.5, 31.8}, {-108.2, 31.3}, {-111.1, 31.3}, {-114.8, 32.5}, {-117.1, 32.5}, {-118.6, 33.8}, {-120.6, 34.4}, {-122.5, 37.2}, {-124.4, 40.3}, {-124.8, 48.4}},
+	},
+}
@@ -0,0 +1,158 @@
+package randomdata
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatLng(t *testing.T) {
+	r := FromSeed(1234)
+	const n = 20000
+	tropics := 0
+	for i := 0; i < n; i++ {
+		p := r.LatLng()
+		assert.InDelta(t, 0, p.Lat, 90)
+		assert.True(t, p.Lng >= -180 && p.Lng < 180, "longitude %v out of range", p.Lng)
+		if math.Abs(p.Lat) < 30 {
+			tropics++
+		}
+	}
+	// Half of the surface of a sphere lies between the 30th parallels.
+	assert.InDelta(t, 0.5, float64(tropics)/n, 0.02)
+}
+
+func TestPointInBBox(t *testing.T) {
+	r := FromSeed(1234)
+	box := BBox{South: 45, West: 5, North: 48, East: 11}
+	for i := 0; i < 1000; i++ {
+		p := r.PointInBBox(box)
+		assert.True(t, p.Lat >= 45 && p.Lat <= 48 && p.Lng >= 5 && p.Lng <= 11, "%v outside %v", p, box)
+	}
+
+	antimeridian := BBox{South: -20, West: 170, North: -10, East: -170}
+	for i := 0; i < 1000; i++ {
+		p := r.PointInBBox(antimeridian)
+		assert.True(t, p.Lng >= 170 || p.Lng <= -170, "%v outside %v", p, antimeridian)
+	}
+}
+
+func TestPointWithinRadius(t *testing.T) {
+	r := FromSeed(1234)
+	center := LatLng{Lat: 51.5074, Lng: -0.1278}
+	const n, radius = 5000, 1000.0
+	total := 0.0
+	for i := 0; i < n; i++ {
+		d := center.DistanceTo(r.PointWithinRadius(center, radius))
+		assert.LessOrEqual(t, d, radius+1e-6)
+		total += d
+	}
+	// Points uniform over a disk are on average at two thirds of its radius.
+	assert.InDelta(t, 2*radius/3, total/n, 20)
+
+	pole := LatLng{Lat: 90}
+	for i := 0; i < 100; i++ {
+		assert.LessOrEqual(t, pole.DistanceTo(r.PointWithinRadius(pole, 5000)), 5000+1e-6)
+	}
+}
+
+func TestPointInPolygon(t *testing.T) {
+	r := FromSeed(1234)
+	polygon := []byte(`{"type":"Feature","properties":{},"geometry":{"type":"Polygon","coordinates":[
+		[[0,0],[10,0],[10,10],[0,10],[0,0]],
+		[[2,2],[8,2],[8,8],[2,8],[2,2]]]}}`)
+	for i := 0; i < 1000; i++ {
+		p, err := r.PointInPolygon(polygon)
+		assert.NoError(t, err)
+		assert.True(t, p.Lat >= 0 && p.Lat <= 10 && p.Lng >= 0 && p.Lng <= 10, "%v outside polygon", p)
+		assert.False(t, p.Lat > 2 && p.Lat < 8 && p.Lng > 2 && p.Lng < 8, "%v inside hole", p)
+	}
+
+	multi := []byte(`{"type":"FeatureCollection","features":[
+		{"type":"Feature","geometry":{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]],[[[20,0],[29,0],[29,9],[20,9],[20,0]]]]}}]}`)
+	large := 0
+	for i := 0; i < 1000; i++ {
+		p, err := r.PointInPolygon(multi)
+		assert.NoError(t, err)
+		if p.Lng >= 20 {
+			large++
+		}
+	}
+	assert.Greater(t, large, 980, "points are not spread by area")
+
+	_, err := r.PointInPolygon([]byte(`{"type":"Point","coordinates":[1,2]}`))
+	assert.Error(t, err)
+	_, err = r.PointInPolygon([]byte(`{"type":"Polygon","coordinates":[[[1,1],[1,1],[1,1],[1,1]]]}`))
+	assert.ErrorIs(t, err, ErrNoArea)
+	_, err = r.PointInPolygon([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestPointInCountry(t *testing.T) {
+	r := FromSeed(1234)
+	assert.Len(t, countryOutlines, len(SupportedPlaceCountries()))
+	for _, country := range SupportedPlaceCountries() {
+		outline := outlinePolygons(countryOutlines[country])
+		for _, e := range jsonData.Gazetteer[country] {
+			city := LatLng{Lat: e.Lat, Lng: e.Lng}
+			contained := false
+			for _, polygon := range outline {
+				contained = contained || polygonContains(polygon, city)
+			}
+			assert.True(t, contained, "%s of %s is outside the outline", e.City, country)
+		}
+
+		polygons, err := parsePolygons(CountryPolygon(country))
+		assert.NoError(t, err)
+		assert.Equal(t, outline, polygons)
+		for i := 0; i < 100; i++ {
+			p := r.PointInCountry(country)
+			contained := false
+			for _, polygon := range outline {
+				contained = contained || polygonContains(polygon, p)
+			}
+			assert.True(t, contained, "%v is outside %s", p, country)
+		}
+	}
+	assert.Equal(t, LatLng{}, r.PointInCountry("bogus"))
+	assert.Nil(t, CountryPolygon("bogus"))
+}
+
+func TestGeohash(t *testing.T) {
+	p := LatLng{Lat: 57.64911, Lng: 10.40744}
+	assert.Equal(t, "u4pruydqqvj", p.Geohash(11))
+	assert.Equal(t, "u4pru", p.Geohash(5))
+	assert.Len(t, p.Geohash(40), 12)
+
+	center, err := DecodeGeohash("u4pruydqqvj")
+	assert.NoError(t, err)
+	assert.Less(t, p.DistanceTo(center), 1.0)
+
+	r := FromSeed(1234)
+	for i := 0; i < 100; i++ {
+		q := r.LatLng()
+		center, err := DecodeGeohash(q.Geohash(8))
+		assert.NoError(t, err)
+		assert.Less(t, q.DistanceTo(center), 30.0)
+	}
+
+	_, err = DecodeGeohash("u4pa")
+	assert.Error(t, err)
+	_, err = DecodeGeohash("")
+	assert.Error(t, err)
+}
+
+func TestLatLngFeature(t *testing.T) {
+	feature, err := LatLng{Lat: 48.8566, Lng: 2.3522}.Feature(map[string]interface{}{"city": "Paris"})
+	assert.NoError(t, err)
+	var decoded map[string]interface{}
+	assert.NoError(t, json.Unmarshal(feature, &decoded))
+	assert.Equal(t, "Feature", decoded["type"])
+	assert.Equal(t, map[string]interface{}{"type": "Point", "coordinates": []interface{}{2.3522, 48.8566}}, decoded["geometry"])
+	assert.Equal(t, map[string]interface{}{"city": "Paris"}, decoded["properties"])
+
+	assert.Equal(t, "48.856600,2.352200", LatLng{Lat: 48.8566, Lng: 2.3522}.String())
+	assert.Equal(t, LatLng{Lat: 1, Lng: 2}, Place{Latitude: 1, Longitude: 2}.LatLng())
+}
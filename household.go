@@ -0,0 +1,172 @@
+package randomdata
+
+import (
+	"time"
+)
+
+// Relation describes how a household member relates to another one.
+type Relation int
+
+const (
+	// Spouse means the members are partners.
+	Spouse Relation = iota
+	// ParentOf means the first member is a parent of the second one.
+	ParentOf
+	// ChildOf means the first member is a child of the second one.
+	ChildOf
+	// SiblingOf means the members share their parents.
+	SiblingOf
+)
+
+// String returns the lower case name of the relation.
+func (rel Relation) String() string {
+	switch rel {
+	case Spouse:
+		return "spouse"
+	case ParentOf:
+		return "parent"
+	case ChildOf:
+		return "child"
+	case SiblingOf:
+		return "sibling"
+	}
+	return "unknown"
+}
+
+// Relationship links two members of a household, From and To are indexes in Household.Members.
+type Relationship struct {
+	From     int      `json:"from"`
+	To       int      `json:"to"`
+	Relation Relation `json:"relation"`
+}
+
+// Household is a group of related profiles living at the same location.
+// Relationships are stored in both directions, e.g. a ParentOf edge always has a matching ChildOf edge.
+type Household struct {
+	Members       []*Profile     `json:"members"`
+	Relationships []Relationship `json:"relationships"`
+}
+
+// HouseholdOptions configures the households generated by Household.
+type HouseholdOptions struct {
+	// GenderRatio is used to pick the gender of every member. The zero value means DefaultGenderRatio.
+	GenderRatio GenderRatio
+	// SingleParentRate is the probability that a household with children has a single adult.
+	SingleParentRate float64
+	// Now is the reference date used to compute the dates of birth. The zero value means time.Now().
+	Now time.Time
+}
+
+// Household generates a household of size members sharing a surname, a location and a landline.
+// The first members are the adults, one or two spouses, followed by their children.
+// Parents are always at least 18 years older than their children, and members under 18 have no employment.
+// A size smaller than 1 is treated as 1.
+func (r *Rand) Household(size int, opts HouseholdOptions) *Household {
+	if size < 1 {
+		size = 1
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	adults := 1
+	if size > 1 && r.Float64() >= opts.SingleParentRate {
+		adults = 2
+	}
+	children := size - adults
+
+	household := &Household{}
+	head := r.GenerateProfile(r.PickGender(opts.GenderRatio))
+
+	minAdultAge := 18
+	if children > 0 {
+		minAdultAge = 20
+	}
+	ages := []int{r.Number(minAdultAge, 61)}
+	if adults == 2 {
+		spouseAge := ages[0] + r.Number(-5, 6)
+		if spouseAge < minAdultAge {
+			spouseAge = minAdultAge
+		}
+		ages = append(ages, spouseAge)
+	}
+	dobs := make([]time.Time, 0, size)
+	for _, age := range ages {
+		dobs = append(dobs, now.AddDate(-age, 0, -r.Intn(365)))
+	}
+	// Children are born at least 18 years after the youngest parent, and not after now.
+	earliestChild := dobs[0]
+	if adults == 2 && dobs[1].After(earliestChild) {
+		earliestChild = dobs[1]
+	}
+	earliestChild = earliestChild.AddDate(18, 0, 0)
+	for i := 0; i < children; i++ {
+		days := int(now.Sub(earliestChild).Hours() / 24)
+		dobs = append(dobs, earliestChild.AddDate(0, 0, r.Intn(days+1)))
+	}
+
+	for i, dob := range dobs {
+		member := head
+		if i > 0 {
+			member = r.GenerateProfile(r.PickGender(opts.GenderRatio))
+			member.Name.Last = head.Name.Last
+			member.Email = r.createEmail(member.Name.First, member.Name.Last)
+			member.Employment.Email = workEmail(member.Name.First, member.Name.Last, member.Employment.Company.Domain)
+			member.Location = head.Location
+			member.Phone = head.Phone
+			member.Nat = head.Nat
+		}
+		member.Dob = dob.Format(DateOutputLayout)
+		age := yearsBetween(dob, now)
+		if age < 12 {
+			member.Cell = ""
+		}
+		// Minors are in school rather than employed.
+		if age < 18 {
+			member.Employment = Profile{}.Employment
+		}
+		household.Members = append(household.Members, member)
+	}
+
+	if adults == 2 {
+		household.link(0, 1, Spouse, Spouse)
+	}
+	for c := adults; c < size; c++ {
+		for p := 0; p < adults; p++ {
+			household.link(p, c, ParentOf, ChildOf)
+		}
+		for s := c + 1; s < size; s++ {
+			household.link(c, s, SiblingOf, SiblingOf)
+		}
+	}
+	return household
+}
+
+// yearsBetween returns the number of full years from one date to another.
+func yearsBetween(from, to time.Time) int {
+	years := to.Year() - from.Year()
+	if to.Month() < from.Month() || to.Month() == from.Month() && to.Day() < from.Day() {
+		years--
+	}
+	return years
+}
+
+func (h *Household) link(from, to int, rel, inverse Relation) {
+	h.Relationships = append(h.Relationships,
+		Relationship{From: from, To: to, Relation: rel},
+		Relationship{From: to, To: from, Relation: inverse},
+	)
+}
+
+// Related returns the indexes of the members that member i has the given relation with,
+// e.g. Related(i, ParentOf) returns the children of member i.
+func (h *Household) Related(i int, rel Relation) []int {
+	var related []int
+	for _, relationship := range h.Relationships {
+		if relationship.From == i && relationship.Relation == rel {
+			related = append(related, relationship.To)
+		}
+	}
+	return related
+}
@@ -0,0 +1,85 @@
+package randomdata
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHousehold(t *testing.T) {
+	r := FromSeed(1234)
+	now := time.Date(2020, time.June, 1, 0, 0, 0, 0, time.UTC)
+	dob := func(p *Profile) time.Time {
+		dob, err := time.Parse(DateOutputLayout, p.Dob)
+		assert.NoError(t, err, "invalid date of birth %q", p.Dob)
+		return dob
+	}
+	age := func(p *Profile) int {
+		return yearsBetween(dob(p), now)
+	}
+
+	for n := 0; n < 300; n++ {
+		size := n%6 + 1
+		h := r.Household(size, HouseholdOptions{Now: now})
+		assert.Len(t, h.Members, size)
+
+		head := h.Members[0]
+		for _, m := range h.Members {
+			assert.Equal(t, head.Name.Last, m.Name.Last, "members should share a surname")
+			assert.Equal(t, head.Location, m.Location, "members should share a location")
+			assert.Equal(t, head.Phone, m.Phone, "members should share a landline")
+		}
+
+		for i := range h.Members {
+			for _, c := range h.Related(i, ParentOf) {
+				assert.GreaterOrEqual(t, yearsBetween(dob(h.Members[i]), dob(h.Members[c])), 18, "parents should be older than their children")
+				assert.False(t, dob(h.Members[c]).After(now), "children should be born")
+				assert.Contains(t, h.Related(c, ChildOf), i, "parent relation should have a matching child relation")
+			}
+			if age(h.Members[i]) < 18 {
+				assert.Empty(t, h.Members[i].Employment.Company.Name, "minors should not be employed")
+				assert.Empty(t, h.Members[i].Employment.JobTitle, "minors should not be employed")
+			}
+			for _, s := range h.Related(i, Spouse) {
+				assert.Contains(t, h.Related(s, Spouse), i, "spouse relation should be symmetric")
+				assert.GreaterOrEqual(t, age(h.Members[s]), 18, "spouses should be adults")
+			}
+		}
+	}
+
+	t.Run("should generate couples and children", func(t *testing.T) {
+		h := r.Household(4, HouseholdOptions{Now: now})
+		assert.Equal(t, []int{1}, h.Related(0, Spouse))
+		assert.Equal(t, []int{2, 3}, h.Related(0, ParentOf))
+		assert.Equal(t, []int{0, 1}, h.Related(3, ChildOf))
+		assert.Equal(t, []int{3}, h.Related(2, SiblingOf))
+	})
+
+	t.Run("should generate single parents", func(t *testing.T) {
+		h := r.Household(3, HouseholdOptions{Now: now, SingleParentRate: 1})
+		assert.Empty(t, h.Related(0, Spouse))
+		assert.Equal(t, []int{1, 2}, h.Related(0, ParentOf))
+	})
+
+	t.Run("should use the gender ratio", func(t *testing.T) {
+		h := r.Household(5, HouseholdOptions{GenderRatio: GenderRatio{NonBinary: 1}})
+		for _, m := range h.Members {
+			assert.Equal(t, "nonbinary", m.Gender)
+		}
+	})
+
+	t.Run("should treat an invalid size as a single member", func(t *testing.T) {
+		h := r.Household(0, HouseholdOptions{})
+		assert.Len(t, h.Members, 1)
+		assert.Empty(t, h.Relationships)
+	})
+}
+
+func TestRelationString(t *testing.T) {
+	assert.Equal(t, "spouse", Spouse.String())
+	assert.Equal(t, "parent", ParentOf.String())
+	assert.Equal(t, "child", ChildOf.String())
+	assert.Equal(t, "sibling", SiblingOf.String())
+	assert.Equal(t, "unknown", Relation(42).String())
+}
@@ -0,0 +1,331 @@
+package randomdata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// HTTPAuth is the way an HTTP request authenticates.
+type HTTPAuth int
+
+const (
+	NoAuth HTTPAuth = iota
+	BasicAuth
+	BearerAuth
+	APIKeyAuth
+)
+
+// String returns the name of the authentication scheme.
+func (a HTTPAuth) String() string {
+	switch a {
+	case NoAuth:
+		return "none"
+	case BasicAuth:
+		return "Basic"
+	case BearerAuth:
+		return "Bearer"
+	case APIKeyAuth:
+		return "API key"
+	}
+	return "unknown"
+}
+
+// HTTPBody is the encoding of the body of an HTTP request.
+type HTTPBody int
+
+const (
+	// AnyBody gives a JSON or form body to the methods which usually carry one, and no body to the others.
+	AnyBody HTTPBody = iota
+	NoBody
+	JSONBody
+	FormBody
+)
+
+// HTTPRequestOptions drives the generation of an HTTP request.
+type HTTPRequestOptions struct {
+	// Method is the HTTP method. The zero value picks one, GET being the most common.
+	Method string
+	// Host is the host the request is sent to. The zero value means a random Hostname.
+	Host string
+	// Auth is the authentication of the request. The zero value means none.
+	Auth HTTPAuth
+	// Cookies is the number of cookies sent with the request.
+	Cookies int
+	// Body is the encoding of the body.
+	Body HTTPBody
+}
+
+// httpMethods weights the methods picked when none is requested.
+var httpMethods = []struct {
+	method string
+	weight int
+}{
+	{http.MethodGet, 65}, {http.MethodPost, 18}, {http.MethodPut, 5}, {http.MethodPatch, 4},
+	{http.MethodDelete, 4}, {http.MethodHead, 3}, {http.MethodOptions, 1},
+}
+
+var (
+	httpCookieNames = []string{"session_id", "_ga", "_gid", "csrftoken", "theme", "lang", "consent", "cart", "ab_test"}
+	httpAccept      = []string{"*/*", "application/json", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"}
+)
+
+// HTTPRequest returns a random HTTP request following the supplied options: a method, a URL with a path and,
+// for GET requests, a query, and the usual headers such as User-Agent and Accept-Language.
+// Requests are only built from the random source, so that a seed replays the same requests.
+// It returns nil if the method is not a valid HTTP token.
+func (r *Rand) HTTPRequest(opts HTTPRequestOptions) *http.Request {
+	method := strings.ToUpper(opts.Method)
+	if method == "" {
+		method = r.httpMethod()
+	}
+	queryParams := 0
+	if method == http.MethodGet {
+		queryParams = r.Intn(3)
+	}
+	u := r.URL(URLOptions{Host: opts.Host, PathSegments: r.Number(1, 5), QueryParams: queryParams})
+
+	bodyType := opts.Body
+	if bodyType == AnyBody {
+		bodyType = NoBody
+		if method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch {
+			bodyType = []HTTPBody{JSONBody, JSONBody, JSONBody, FormBody}[r.Intn(4)]
+		}
+	}
+	var body []byte
+	contentType := ""
+	switch bodyType {
+	case JSONBody:
+		body, contentType = r.httpJSON(), "application/json"
+	case FormBody:
+		body, contentType = []byte(r.httpForm().Encode()), "application/x-www-form-urlencoded"
+	}
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil
+	}
+	if body == nil {
+		req.Body, req.GetBody, req.ContentLength = http.NoBody, nil, 0
+	}
+	ua, _ := r.UserAgent(UserAgentOptions{})
+	req.Header.Set("User-Agent", ua)
+	req.Header.Set("Accept", r.StringFrom(httpAccept))
+	req.Header.Set("Accept-Language", r.acceptLanguage())
+	req.Header.Set("Accept-Encoding", "gzip, deflate, br")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	switch opts.Auth {
+	case BasicAuth:
+		req.SetBasicAuth(emailAtom(r.FirstName(RandomGender)), r.httpToken(12))
+	case BearerAuth:
+		req.Header.Set("Authorization", "Bearer "+r.httpToken(32))
+	case APIKeyAuth:
+		req.Header.Set("X-API-Key", hex.EncodeToString(r.randomBytes(16)))
+	}
+	first := r.Intn(len(httpCookieNames))
+	for i := 0; i < opts.Cookies; i++ {
+		name := httpCookieNames[(first+i)%len(httpCookieNames)]
+		if i >= len(httpCookieNames) {
+			name += strconv.Itoa(i / len(httpCookieNames))
+		}
+		req.AddCookie(&http.Cookie{Name: name, Value: r.httpToken(16)})
+	}
+	req.Header.Set("X-Request-Id", hex.EncodeToString(r.randomBytes(16)))
+	return req
+}
+
+// httpMethod returns a method following the weights of httpMethods.
+func (r *Rand) httpMethod() string {
+	total := 0
+	for _, m := range httpMethods {
+		total += m.weight
+	}
+	pick := r.Intn(total)
+	for _, m := range httpMethods {
+		if pick -= m.weight; pick < 0 {
+			return m.method
+		}
+	}
+	return http.MethodGet
+}
+
+// acceptLanguage returns an Accept-Language header built around a random locale, e.g. "fr-CH,fr;q=0.9,en;q=0.8".
+func (r *Rand) acceptLanguage() string {
+	locale := r.Locale()
+	language := strings.SplitN(locale, "-", 2)[0]
+	header := locale
+	if language != locale {
+		header += "," + language + ";q=0.9"
+	}
+	if language != "en" {
+		header += ",en;q=0.8"
+	}
+	return header
+}
+
+// httpJSON returns a JSON object describing a random user, as often sent to APIs.
+func (r *Rand) httpJSON() []byte {
+	gender := Gender(r.Intn(2))
+	first, last := r.FirstName(gender), r.LastName()
+	body, _ := json.Marshal(map[string]interface{}{
+		"id":     r.Number(1, 1000000),
+		"name":   first + " " + last,
+		"email":  workEmail(first, last, r.StringFrom(jsonData.Domains)),
+		"active": r.Boolean(),
+		"tags":   []string{r.Adjective(), r.Noun()},
+		"score":  float64(r.Intn(10000)) / 100,
+	})
+	return body
+}
+
+// httpForm returns the fields of a contact form.
+func (r *Rand) httpForm() url.Values {
+	gender := Gender(r.Intn(2))
+	first, last := r.FirstName(gender), r.LastName()
+	return url.Values{
+		"name":    {first + " " + last},
+		"email":   {workEmail(first, last, r.StringFrom(jsonData.Domains))},
+		"subject": {uppercaseFirstLetter(r.Adjective()) + " " + r.Noun()},
+		"message": {r.Paragraph()},
+	}
+}
+
+// httpToken returns an opaque URL-safe token made of n random bytes.
+func (r *Rand) httpToken(n int) string {
+	return base64.RawURLEncoding.EncodeToString(r.randomBytes(n))
+}
+
+type httpStatus struct {
+	code    int
+	weight  int
+	methods string // the methods getting the status, all of them when empty
+}
+
+// answers tells whether the status is a possible answer to the method.
+func (s httpStatus) answers(method string) bool {
+	if s.methods == "" {
+		return true
+	}
+	for _, m := range strings.Fields(s.methods) {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// httpStatuses weights the status codes of responses, per method when it matters.
+var httpStatuses = []httpStatus{
+	{http.StatusOK, 800, "GET HEAD OPTIONS PUT PATCH POST"},
+	{http.StatusCreated, 600, "POST PUT"},
+	{http.StatusNoContent, 600, "DELETE OPTIONS PUT PATCH"},
+	{http.StatusMovedPermanently, 8, "GET HEAD"},
+	{http.StatusFound, 15, "GET HEAD POST"},
+	{http.StatusNotModified, 40, "GET HEAD"},
+	{http.StatusBadRequest, 20, ""},
+	{http.StatusUnauthorized, 15, ""},
+	{http.StatusForbidden, 10, ""},
+	{http.StatusNotFound, 50, ""},
+	{http.StatusMethodNotAllowed, 2, ""},
+	{http.StatusConflict, 8, "POST PUT PATCH DELETE"},
+	{http.StatusUnprocessableEntity, 15, "POST PUT PATCH"},
+	{http.StatusTooManyRequests, 5, ""},
+	{http.StatusInternalServerError, 8, ""},
+	{http.StatusBadGateway, 3, ""},
+	{http.StatusServiceUnavailable, 3, ""},
+	{http.StatusGatewayTimeout, 2, ""},
+}
+
+// HTTPStatusCode returns a status code answering a request of the supplied method, following the distribution
+// observed on web servers: mostly successes, with some redirections, client errors and a few server errors.
+// Successful creations and deletions get 201 and 204, other methods are answered like GET.
+func (r *Rand) HTTPStatusCode(method string) int {
+	method = strings.ToUpper(method)
+	known := false
+	for _, m := range httpMethods {
+		known = known || m.method == method
+	}
+	if !known {
+		method = http.MethodGet
+	}
+	total := 0
+	for _, s := range httpStatuses {
+		if s.answers(method) {
+			total += s.weight
+		}
+	}
+	pick := r.Intn(total)
+	for _, s := range httpStatuses {
+		if !s.answers(method) {
+			continue
+		}
+		if pick -= s.weight; pick < 0 {
+			return s.code
+		}
+	}
+	return http.StatusOK
+}
+
+// HTTPResponse returns a random response to the request, with a status code from HTTPStatusCode,
+// the headers matching it and a JSON body. The request may be nil, a GET being assumed.
+func (r *Rand) HTTPResponse(req *http.Request) *http.Response {
+	method := http.MethodGet
+	if req != nil {
+		method = req.Method
+	}
+	code := r.HTTPStatusCode(method)
+	resp := &http.Response{
+		Status:     fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		StatusCode: code,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Request:    req,
+	}
+	var body []byte
+	switch {
+	case bodilessStatus(code):
+	case code >= 400:
+		body, _ = json.Marshal(map[string]interface{}{"status": code, "error": http.StatusText(code)})
+	case code >= 300:
+		resp.Header.Set("Location", r.URL(URLOptions{PathSegments: r.Number(1, 4)}).String())
+	default:
+		body = r.httpJSON()
+	}
+	switch code {
+	case http.StatusUnauthorized:
+		resp.Header.Set("WWW-Authenticate", `Bearer realm="api"`)
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		resp.Header.Set("Retry-After", strconv.Itoa(r.Number(1, 121)))
+	case http.StatusOK, http.StatusNotModified:
+		resp.Header.Set("ETag", `"`+hex.EncodeToString(r.randomBytes(8))+`"`)
+	}
+	if body != nil {
+		resp.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	resp.ContentLength = int64(len(body))
+	if !bodilessStatus(code) {
+		resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+	if method == http.MethodHead {
+		body = nil
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp
+}
+
+// bodilessStatus reports whether a response of the status code has no body, so no Content-Length either,
+// following RFC 9110: informational, 204 No Content and 304 Not Modified responses.
+func bodilessStatus(code int) bool {
+	return code < 200 || code == http.StatusNoContent || code == http.StatusNotModified
+}
@@ -0,0 +1,165 @@
+package randomdata
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPRequest(t *testing.T) {
+	r := FromSeed(1234)
+	methods := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		req := r.HTTPRequest(HTTPRequestOptions{})
+		if !assert.NotNil(t, req) {
+			continue
+		}
+		methods[req.Method]++
+		assert.True(t, isHostname(req.URL.Host), "invalid host %q", req.URL.Host)
+		assert.NotEmpty(t, req.URL.Path)
+		assert.NotEmpty(t, req.Header.Get("User-Agent"))
+		assert.NotEmpty(t, req.Header.Get("Accept-Language"))
+		if req.Method != http.MethodGet {
+			assert.Empty(t, req.URL.RawQuery)
+		}
+		body, err := io.ReadAll(req.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, req.ContentLength, int64(len(body)))
+		switch req.Header.Get("Content-Type") {
+		case "application/json":
+			var object map[string]interface{}
+			assert.NoError(t, json.Unmarshal(body, &object), string(body))
+			assert.Contains(t, object, "email")
+		case "application/x-www-form-urlencoded":
+			form, err := url.ParseQuery(string(body))
+			assert.NoError(t, err)
+			assert.NotEmpty(t, form.Get("email"))
+		default:
+			assert.Empty(t, body)
+			assert.NotContains(t, []string{http.MethodPost, http.MethodPut, http.MethodPatch}, req.Method)
+		}
+	}
+	assert.Greater(t, methods[http.MethodGet], methods[http.MethodPost])
+	assert.Greater(t, methods[http.MethodPost], 0)
+	assert.Greater(t, methods[http.MethodDelete], 0)
+}
+
+func TestHTTPRequestOptions(t *testing.T) {
+	r := FromSeed(1234)
+	req := r.HTTPRequest(HTTPRequestOptions{Method: "put", Host: "api.example.com", Auth: BasicAuth, Cookies: 3, Body: FormBody})
+	assert.Equal(t, http.MethodPut, req.Method)
+	assert.Equal(t, "api.example.com", req.URL.Host)
+	assert.Equal(t, "application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+	_, _, ok := req.BasicAuth()
+	assert.True(t, ok)
+	assert.Len(t, req.Cookies(), 3)
+
+	req = r.HTTPRequest(HTTPRequestOptions{Method: http.MethodGet, Auth: BearerAuth, Cookies: 20, Body: JSONBody})
+	assert.True(t, strings.HasPrefix(req.Header.Get("Authorization"), "Bearer "))
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	names := map[string]bool{}
+	for _, cookie := range req.Cookies() {
+		names[cookie.Name] = true
+	}
+	assert.Len(t, names, 20)
+
+	req = r.HTTPRequest(HTTPRequestOptions{Method: http.MethodPost, Auth: APIKeyAuth, Body: NoBody})
+	assert.Len(t, req.Header.Get("X-API-Key"), 32)
+	assert.Equal(t, http.NoBody, req.Body)
+
+	assert.Nil(t, r.HTTPRequest(HTTPRequestOptions{Method: "BAD METHOD"}))
+}
+
+func TestHTTPRequestReplay(t *testing.T) {
+	dump := func(seed int64) string {
+		r := FromSeed(seed)
+		var dumps []string
+		for i := 0; i < 20; i++ {
+			req := r.HTTPRequest(HTTPRequestOptions{Auth: BearerAuth, Cookies: 2})
+			b, err := httputil.DumpRequest(req, true)
+			assert.NoError(t, err)
+			resp, err := httputil.DumpResponse(r.HTTPResponse(req), true)
+			assert.NoError(t, err)
+			dumps = append(dumps, string(b), string(resp))
+		}
+		return strings.Join(dumps, "\n")
+	}
+	assert.Equal(t, dump(1234), dump(1234))
+	assert.NotEqual(t, dump(1234), dump(4321))
+}
+
+func TestHTTPStatusCode(t *testing.T) {
+	r := FromSeed(1234)
+	const n = 10000
+	classes := map[int]int{}
+	for i := 0; i < n; i++ {
+		code := r.HTTPStatusCode(http.MethodGet)
+		assert.NotEqual(t, http.StatusCreated, code)
+		assert.NotEmpty(t, http.StatusText(code))
+		classes[code/100]++
+	}
+	assert.Greater(t, classes[2], n*3/4)
+	assert.Greater(t, classes[3], 0)
+	assert.Greater(t, classes[4], classes[5])
+	assert.Greater(t, classes[5], 0)
+
+	created, noContent := 0, 0
+	for i := 0; i < 1000; i++ {
+		if r.HTTPStatusCode("post") == http.StatusCreated {
+			created++
+		}
+		if r.HTTPStatusCode(http.MethodDelete) == http.StatusNoContent {
+			noContent++
+		}
+		assert.NotEqual(t, http.StatusNotModified, r.HTTPStatusCode(http.MethodPost))
+	}
+	assert.Greater(t, created, 300)
+	assert.Greater(t, noContent, 700)
+}
+
+func TestHTTPResponse(t *testing.T) {
+	r := FromSeed(1234)
+	for i := 0; i < 1000; i++ {
+		req := r.HTTPRequest(HTTPRequestOptions{})
+		resp := r.HTTPResponse(req)
+		assert.Equal(t, req, resp.Request)
+		assert.Equal(t, strconv.Itoa(resp.StatusCode)+" "+http.StatusText(resp.StatusCode), resp.Status)
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		switch {
+		case req.Method == http.MethodHead:
+			assert.Empty(t, body)
+		case resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotModified:
+			assert.Empty(t, body)
+			assert.Zero(t, resp.ContentLength)
+			assert.NotContains(t, resp.Header, "Content-Length")
+		case resp.StatusCode >= 300 && resp.StatusCode < 400:
+			_, err := url.Parse(resp.Header.Get("Location"))
+			assert.NoError(t, err)
+			assert.NotEmpty(t, resp.Header.Get("Location"))
+		default:
+			assert.Equal(t, resp.ContentLength, int64(len(body)))
+			assert.Equal(t, strconv.Itoa(len(body)), resp.Header.Get("Content-Length"))
+			assert.True(t, json.Valid(body), string(body))
+		}
+	}
+	resp := r.HTTPResponse(nil)
+	assert.Nil(t, resp.Request)
+	assert.NotZero(t, resp.StatusCode)
+}
+
+func TestBodilessStatus(t *testing.T) {
+	for _, code := range []int{http.StatusContinue, http.StatusSwitchingProtocols, http.StatusEarlyHints, http.StatusNoContent, http.StatusNotModified} {
+		assert.True(t, bodilessStatus(code), code)
+	}
+	for _, code := range []int{http.StatusOK, http.StatusCreated, http.StatusFound, http.StatusNotFound} {
+		assert.False(t, bodilessStatus(code), code)
+	}
+}
@@ -0,0 +1,150 @@
+package randomdata
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+)
+
+// Formats obtained from:
+// * https://www.rfc-editor.org/rfc/rfc9562 (UUID versions 4 and 7)
+// * https://github.com/ulid/spec
+// * https://github.com/segmentio/ksuid
+// * https://github.com/twitter-archive/snowflake
+// * https://github.com/ai/nanoid
+//
+// Time-based identifiers take their time as an argument, so they can follow a simulated clock,
+// and sort in the order of their times. Identifiers of the same millisecond, or second for KSUIDs,
+// are in random order.
+
+const (
+	// crockfordAlphabet is the base 32 alphabet of ULIDs, without I, L, O and U.
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// base62Alphabet is the base 62 alphabet of KSUIDs and GitHub tokens, in ASCII order so that KSUIDs sort as strings.
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// ksuidEpoch is the start of the KSUID timestamps, in seconds since the Unix epoch.
+	ksuidEpoch = 1400000000
+	// snowflakeEpoch is the start of the Twitter snowflake timestamps, in milliseconds since the Unix epoch.
+	snowflakeEpoch = 1288834974657
+	// nanoIDAlphabet is the URL-safe default alphabet of NanoID.
+	nanoIDAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789_-"
+)
+
+// UUIDv4 returns a random version 4 UUID, e.g. "0b6c2a4e-3f1d-4c8a-9e57-6a1f0d2b3c4d".
+func (r *Rand) UUIDv4() string {
+	b := r.randomBytes(16)
+	return formatUUID(b, 4)
+}
+
+// UUIDv7 returns a version 7 UUID, starting with the Unix time of t in milliseconds followed by random bits.
+// If t is before the Unix epoch it will return an empty string.
+func (r *Rand) UUIDv7(t time.Time) string {
+	ms := t.UnixMilli()
+	if ms < 0 || ms >= 1<<48 {
+		return ""
+	}
+	b := r.randomBytes(16)
+	putUint48(b, uint64(ms))
+	return formatUUID(b, 7)
+}
+
+// formatUUID sets the version and the RFC 9562 variant of the 16 bytes and writes them in the 8-4-4-4-12 form.
+func formatUUID(b []byte, version byte) string {
+	b[6] = b[6]&0x0f | version<<4
+	b[8] = b[8]&0x3f | 0x80
+	s := hex.EncodeToString(b)
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// ULID returns a universally unique lexicographically sortable identifier: the Unix time of t in milliseconds
+// followed by 80 random bits, as 26 characters of Crockford's base 32, e.g. "01HV3K9Q8ZJ4W6T2N5R7XBCDEF".
+// If t is before the Unix epoch it will return an empty string.
+func (r *Rand) ULID(t time.Time) string {
+	ms := t.UnixMilli()
+	if ms < 0 || ms >= 1<<48 {
+		return ""
+	}
+	b := r.randomBytes(16)
+	putUint48(b, uint64(ms))
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	// The 128 bits are written from the most significant one, the first character holding only 3 bits.
+	s := make([]byte, 26)
+	for i := range s {
+		shift := uint(125 - 5*i)
+		var v uint64
+		switch {
+		case shift >= 64:
+			v = hi >> (shift - 64)
+		case shift > 59:
+			v = hi<<(64-shift) | lo>>shift
+		default:
+			v = lo >> shift
+		}
+		s[i] = crockfordAlphabet[v&0x1f]
+	}
+	return string(s)
+}
+
+// KSUID returns a K-sortable unique identifier: the seconds of t since the KSUID epoch, 13 May 2014,
+// followed by 128 random bits, as 27 characters of base 62, e.g. "2fS8Jp4mXk0Qb9LrT3vWc7NhZyE".
+// If t is outside the range of KSUID timestamps, from 2014 to 2150, it will return an empty string.
+func (r *Rand) KSUID(t time.Time) string {
+	seconds := t.Unix() - ksuidEpoch
+	if seconds < 0 || seconds >= 1<<32 {
+		return ""
+	}
+	b := r.randomBytes(20)
+	binary.BigEndian.PutUint32(b, uint32(seconds))
+	// Repeated division of the big-endian number by 62, the remainders being the digits from the last one.
+	s := make([]byte, 27)
+	for i := len(s) - 1; i >= 0; i-- {
+		remainder := 0
+		for j, v := range b {
+			value := remainder<<8 | int(v)
+			b[j] = byte(value / 62)
+			remainder = value % 62
+		}
+		s[i] = base62Alphabet[remainder]
+	}
+	return string(s)
+}
+
+// Snowflake returns a Twitter-style snowflake ID: 41 bits of milliseconds of t since the Twitter epoch,
+// 4 November 2010, 10 bits of node and 12 bits of random sequence number.
+// If the node is not within 0-1023 or t is outside the range of snowflake timestamps it will return 0.
+func (r *Rand) Snowflake(t time.Time, node int) int64 {
+	ms := t.UnixMilli() - snowflakeEpoch
+	if ms < 0 || ms >= 1<<41 || node < 0 || node >= 1<<10 {
+		return 0
+	}
+	return ms<<22 | int64(node)<<12 | int64(r.Intn(1<<12))
+}
+
+// NanoID returns a random identifier of size characters drawn from the supplied alphabet, e.g. "V1StGXR8_Z5jdHi6B-myT".
+// A zero size means the default of 21 characters and an empty alphabet means the URL-safe alphabet of
+// letters, digits, '_' and '-'. The alphabet may hold any Unicode characters.
+// If the size is negative it will return an empty string.
+func (r *Rand) NanoID(size int, alphabet string) string {
+	if size < 0 {
+		return ""
+	}
+	if size == 0 {
+		size = 21
+	}
+	if alphabet == "" {
+		alphabet = nanoIDAlphabet
+	}
+	characters := []rune(alphabet)
+	id := make([]rune, size)
+	for i := range id {
+		id[i] = characters[r.Intn(len(characters))]
+	}
+	return string(id)
+}
+
+// putUint48 writes the 48 least significant bits of v at the start of b, most significant byte first.
+func putUint48(b []byte, v uint64) {
+	for i := 0; i < 6; i++ {
+		b[i] = byte(v >> uint(40-8*i))
+	}
+}
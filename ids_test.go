@@ -0,0 +1,118 @@
+package randomdata
+
+import (
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUUID(t *testing.T) {
+	r := FromSeed(1234)
+	seen := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		id := r.UUIDv4()
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, id)
+		assert.False(t, seen[id], "duplicate %s", id)
+		seen[id] = true
+	}
+	assert.Equal(t, FromSeed(42).UUIDv4(), FromSeed(42).UUIDv4())
+
+	at := time.Date(2024, 2, 29, 13, 37, 0, 123456789, time.UTC)
+	id := r.UUIDv7(at)
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, id)
+	ms, err := strconv.ParseInt(strings.Replace(id[:13], "-", "", 1), 16, 64)
+	assert.NoError(t, err)
+	assert.Equal(t, at.UnixMilli(), ms)
+	assertSorted(t, func(at time.Time) string { return r.UUIDv7(at) })
+	assert.Equal(t, "", r.UUIDv7(time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestULID(t *testing.T) {
+	r := FromSeed(1234)
+	at := time.Date(2024, 2, 29, 13, 37, 0, 123456789, time.UTC)
+	for i := 0; i < 100; i++ {
+		id := r.ULID(at)
+		assert.Regexp(t, `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`, id)
+		assert.Equal(t, at.UnixMilli(), decodeBase(id[:10], crockfordAlphabet).Int64())
+	}
+	// The timestamp of the specification example.
+	assert.Equal(t, "01ARZ3NDEK", r.ULID(time.UnixMilli(1469922850259))[:10])
+	assert.Equal(t, "7ZZZZZZZZZ", r.ULID(time.UnixMilli(1<<48 - 1))[:10])
+	assertSorted(t, func(at time.Time) string { return r.ULID(at) })
+	assert.Equal(t, "", r.ULID(time.UnixMilli(-1)))
+	assert.Equal(t, FromSeed(42).ULID(at), FromSeed(42).ULID(at))
+}
+
+func TestKSUID(t *testing.T) {
+	r := FromSeed(1234)
+	at := time.Date(2024, 2, 29, 13, 37, 0, 0, time.UTC)
+	for i := 0; i < 100; i++ {
+		id := r.KSUID(at)
+		assert.Regexp(t, `^[0-9A-Za-z]{27}$`, id)
+		b := decodeBase(id, base62Alphabet).FillBytes(make([]byte, 20))
+		assert.Equal(t, at.Unix()-ksuidEpoch, new(big.Int).SetBytes(b[:4]).Int64())
+	}
+	assert.Equal(t, "00000", r.KSUID(time.Unix(ksuidEpoch, 0))[:5])
+	assert.LessOrEqual(t, r.KSUID(time.Unix(ksuidEpoch+1<<32-1, 0)), "aWgEPTl1tmebfsQzFP4bxwgy80V")
+	assertSorted(t, func(at time.Time) string { return r.KSUID(at.Truncate(time.Second)) })
+	assert.Equal(t, "", r.KSUID(time.Unix(ksuidEpoch-1, 0)))
+	assert.Equal(t, "", r.KSUID(time.Unix(ksuidEpoch+1<<32, 0)))
+}
+
+func TestSnowflake(t *testing.T) {
+	r := FromSeed(1234)
+	at := time.Date(2024, 2, 29, 13, 37, 0, 123456789, time.UTC)
+	for _, node := range []int{0, 1, 513, 1023} {
+		id := r.Snowflake(at, node)
+		assert.Equal(t, at.UnixMilli(), id>>22+snowflakeEpoch)
+		assert.Equal(t, int64(node), id>>12&0x3ff)
+	}
+	var last int64
+	for i := 0; i < 100; i++ {
+		id := r.Snowflake(at.Add(time.Duration(i)*time.Millisecond), 7)
+		assert.Greater(t, id, last)
+		last = id
+	}
+	assert.Zero(t, r.Snowflake(at, -1))
+	assert.Zero(t, r.Snowflake(at, 1024))
+	assert.Zero(t, r.Snowflake(time.UnixMilli(snowflakeEpoch-1), 0))
+}
+
+func TestNanoID(t *testing.T) {
+	r := FromSeed(1234)
+	for i := 0; i < 100; i++ {
+		assert.Regexp(t, `^[A-Za-z0-9_-]{21}$`, r.NanoID(0, ""))
+		assert.Regexp(t, `^[0-9a-f]{12}$`, r.NanoID(12, "0123456789abcdef"))
+		id := r.NanoID(8, "αβγδ")
+		assert.Equal(t, 8, utf8.RuneCountInString(id))
+		assert.Empty(t, strings.Trim(id, "αβγδ"))
+	}
+	assert.Equal(t, "", r.NanoID(-1, ""))
+	assert.Equal(t, FromSeed(42).NanoID(0, ""), FromSeed(42).NanoID(0, ""))
+}
+
+// assertSorted checks that identifiers generated at increasing times sort in the same order as strings.
+func assertSorted(t *testing.T, generate func(at time.Time) string) {
+	at := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	ids := make([]string, 200)
+	for i := range ids {
+		ids[i] = generate(at)
+		at = at.Add(time.Duration(i%5+1) * 997 * time.Millisecond)
+	}
+	assert.True(t, sort.StringsAreSorted(ids), "%v", ids)
+}
+
+// decodeBase returns the value of s written with the digits of alphabet.
+func decodeBase(s, alphabet string) *big.Int {
+	value, base := new(big.Int), big.NewInt(int64(len(alphabet)))
+	for _, c := range s {
+		value.Mul(value, base).Add(value, big.NewInt(int64(strings.IndexRune(alphabet, c))))
+	}
+	return value
+}
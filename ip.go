@@ -0,0 +1,191 @@
+package randomdata
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// Address ranges obtained from:
+// * https://www.iana.org/assignments/iana-ipv4-special-registry
+// * https://www.iana.org/assignments/iana-ipv6-special-registry
+
+// AddrClass decides which range an IP address is picked from.
+type AddrClass int
+
+const (
+	// AnyAddress picks an address among all the addresses of the family.
+	AnyAddress AddrClass = iota
+	// PublicAddress picks a globally routable unicast address.
+	PublicAddress
+	// PrivateAddress picks an address of the private networks (RFC 1918) or unique local addresses (RFC 4193).
+	PrivateAddress
+	// LoopbackAddress picks an address of the host itself.
+	LoopbackAddress
+	// LinkLocalAddress picks a link-local unicast address (RFC 3927, RFC 4291).
+	LinkLocalAddress
+	// MulticastAddress picks a multicast group address.
+	MulticastAddress
+	// DocumentationAddress picks an address reserved for documentation (RFC 5737, RFC 3849, RFC 9637).
+	DocumentationAddress
+)
+
+// String returns the name of the address class.
+func (c AddrClass) String() string {
+	switch c {
+	case AnyAddress:
+		return "any"
+	case PublicAddress:
+		return "public"
+	case PrivateAddress:
+		return "private"
+	case LoopbackAddress:
+		return "loopback"
+	case LinkLocalAddress:
+		return "link-local"
+	case MulticastAddress:
+		return "multicast"
+	case DocumentationAddress:
+		return "documentation"
+	}
+	return "unknown"
+}
+
+var (
+	ipv4Ranges = map[AddrClass][]netip.Prefix{
+		AnyAddress:           prefixes("0.0.0.0/0"),
+		PrivateAddress:       prefixes("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"),
+		LoopbackAddress:      prefixes("127.0.0.0/8"),
+		LinkLocalAddress:     prefixes("169.254.0.0/16"),
+		MulticastAddress:     prefixes("224.0.0.0/4"),
+		DocumentationAddress: prefixes("192.0.2.0/24", "198.51.100.0/24", "203.0.113.0/24"),
+	}
+	ipv6Ranges = map[AddrClass][]netip.Prefix{
+		AnyAddress:           prefixes("::/0"),
+		PublicAddress:        prefixes("2000::/3"),
+		PrivateAddress:       prefixes("fd00::/8"),
+		LoopbackAddress:      prefixes("::1/128"),
+		LinkLocalAddress:     prefixes("fe80::/64"),
+		MulticastAddress:     prefixes("ff00::/8"),
+		DocumentationAddress: prefixes("2001:db8::/32", "3fff::/20"),
+	}
+
+	// ipv4Reserved are the special-purpose ranges which are not globally reachable.
+	ipv4Reserved = prefixes("0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16",
+		"172.16.0.0/12", "192.0.0.0/24", "192.0.2.0/24", "192.88.99.0/24", "192.168.0.0/16", "198.18.0.0/15",
+		"198.51.100.0/24", "203.0.113.0/24", "224.0.0.0/4", "240.0.0.0/4")
+	// ipv6Reserved are the special-purpose ranges of the global unicast range which are not globally reachable.
+	ipv6Reserved = prefixes("2001::/23", "2001:db8::/32", "2002::/16", "3fff::/20")
+)
+
+func prefixes(cidrs ...string) []netip.Prefix {
+	list := make([]netip.Prefix, len(cidrs))
+	for i, cidr := range cidrs {
+		list[i] = netip.MustParsePrefix(cidr)
+	}
+	return list
+}
+
+// IPv4 returns a random IPv4 address of the supplied class.
+func (r *Rand) IPv4(class AddrClass) netip.Addr {
+	switch class {
+	case PublicAddress:
+		return r.addrOutside(ipv4Ranges[AnyAddress][0], ipv4Reserved)
+	case LinkLocalAddress:
+		// The first and last 256 addresses are reserved by RFC 3927.
+		return netip.AddrFrom4([4]byte{169, 254, byte(r.Number(1, 255)), byte(r.Intn(256))})
+	}
+	return r.addrIn(ipv4Ranges[class])
+}
+
+// IPv6 returns a random IPv6 address of the supplied class.
+func (r *Rand) IPv6(class AddrClass) netip.Addr {
+	if class == PublicAddress {
+		return r.addrOutside(ipv6Ranges[PublicAddress][0], ipv6Reserved)
+	}
+	return r.addrIn(ipv6Ranges[class])
+}
+
+// IPv4MappedIPv6 returns a random IPv4 address of the supplied class mapped into IPv6, e.g. ::ffff:192.0.2.1.
+func (r *Rand) IPv4MappedIPv6(class AddrClass) netip.Addr {
+	return netip.AddrFrom16(r.IPv4(class).As16())
+}
+
+// IPInPrefix returns a random address within the supplied prefix, e.g. 10.1.0.0/16.
+// It returns the zero netip.Addr if the prefix is not valid.
+func (r *Rand) IPInPrefix(prefix netip.Prefix) netip.Addr {
+	if !prefix.IsValid() {
+		return netip.Addr{}
+	}
+	return r.randomizeHost(prefix.Masked())
+}
+
+// IPv4Prefix returns a random prefix of the supplied length whose addresses are of the supplied class,
+// e.g. a /24 in a private network. When the prefix is shorter than the range of the class, the prefix
+// holds the range and more.
+func (r *Rand) IPv4Prefix(class AddrClass, bits int) (netip.Prefix, error) {
+	return r.IPv4(class).Prefix(bits)
+}
+
+// IPv6Prefix returns a random prefix of the supplied length whose addresses are of the supplied class.
+// See IPv4Prefix.
+func (r *Rand) IPv6Prefix(class AddrClass, bits int) (netip.Prefix, error) {
+	return r.IPv6(class).Prefix(bits)
+}
+
+// SubnetOf returns a random subnet of the supplied length within the supplied prefix,
+// e.g. a /24 within 10.0.0.0/8.
+func (r *Rand) SubnetOf(prefix netip.Prefix, bits int) (netip.Prefix, error) {
+	if !prefix.IsValid() {
+		return netip.Prefix{}, fmt.Errorf("randomdata: invalid prefix %v", prefix)
+	}
+	if bits < prefix.Bits() || bits > prefix.Addr().BitLen() {
+		return netip.Prefix{}, fmt.Errorf("randomdata: a /%d can't be a subnet of %v", bits, prefix)
+	}
+	return r.IPInPrefix(prefix).Prefix(bits)
+}
+
+// addrIn returns an address in one of the ranges, each range being picked with the same probability.
+func (r *Rand) addrIn(ranges []netip.Prefix) netip.Addr {
+	if len(ranges) == 0 {
+		return netip.Addr{}
+	}
+	return r.randomizeHost(ranges[r.Intn(len(ranges))])
+}
+
+// addrOutside returns an address in the prefix but outside all the excluded ranges.
+func (r *Rand) addrOutside(prefix netip.Prefix, excluded []netip.Prefix) netip.Addr {
+	for {
+		addr := r.randomizeHost(prefix)
+		if !prefixContains(excluded, addr) {
+			return addr
+		}
+	}
+}
+
+// randomizeHost replaces the bits of the address after the prefix length by random ones.
+func (r *Rand) randomizeHost(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().AsSlice()
+	for i := range bytes {
+		fixed := prefix.Bits() - 8*i
+		if fixed >= 8 {
+			continue
+		}
+		mask := byte(0xff)
+		if fixed > 0 {
+			mask >>= uint(fixed)
+		}
+		bytes[i] = bytes[i]&^mask | byte(r.Intn(256))&mask
+	}
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
+
+// prefixContains tells whether one of the prefixes holds the address.
+func prefixContains(list []netip.Prefix, addr netip.Addr) bool {
+	for _, p := range list {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
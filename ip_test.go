@@ -0,0 +1,144 @@
+package randomdata
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPv4(t *testing.T) {
+	r := FromSeed(1234)
+	checks := map[AddrClass]func(netip.Addr) bool{
+		AnyAddress:       netip.Addr.Is4,
+		PublicAddress:    func(a netip.Addr) bool { return a.IsGlobalUnicast() && !a.IsPrivate() },
+		PrivateAddress:   netip.Addr.IsPrivate,
+		LoopbackAddress:  netip.Addr.IsLoopback,
+		LinkLocalAddress: netip.Addr.IsLinkLocalUnicast,
+		MulticastAddress: netip.Addr.IsMulticast,
+		DocumentationAddress: func(a netip.Addr) bool {
+			return prefixContains(prefixes("192.0.2.0/24", "198.51.100.0/24", "203.0.113.0/24"), a)
+		},
+	}
+	for class, check := range checks {
+		for i := 0; i < 1000; i++ {
+			addr := r.IPv4(class)
+			assert.True(t, addr.Is4(), "%v is not an IPv4 address", addr)
+			assert.True(t, check(addr), "%v is not a %v address", addr, class)
+		}
+	}
+}
+
+func TestIPv4Public(t *testing.T) {
+	r := FromSeed(1234)
+	for i := 0; i < 10000; i++ {
+		addr := r.IPv4(PublicAddress)
+		assert.False(t, prefixContains(ipv4Reserved, addr), "%v is reserved", addr)
+	}
+}
+
+func TestIPv4AllOctets(t *testing.T) {
+	r := FromSeed(1234)
+	seen := map[byte]bool{}
+	for i := 0; i < 10000; i++ {
+		for _, b := range r.IPv4(AnyAddress).As4() {
+			seen[b] = true
+		}
+	}
+	assert.Len(t, seen, 256)
+}
+
+func TestIPv6(t *testing.T) {
+	r := FromSeed(1234)
+	checks := map[AddrClass]func(netip.Addr) bool{
+		AnyAddress:       netip.Addr.Is6,
+		PublicAddress:    func(a netip.Addr) bool { return a.IsGlobalUnicast() && !a.IsPrivate() },
+		PrivateAddress:   netip.Addr.IsPrivate,
+		LoopbackAddress:  netip.Addr.IsLoopback,
+		LinkLocalAddress: netip.Addr.IsLinkLocalUnicast,
+		MulticastAddress: netip.Addr.IsMulticast,
+		DocumentationAddress: func(a netip.Addr) bool {
+			return prefixContains(prefixes("2001:db8::/32", "3fff::/20"), a)
+		},
+	}
+	for class, check := range checks {
+		for i := 0; i < 1000; i++ {
+			addr := r.IPv6(class)
+			assert.True(t, addr.Is6() && !addr.Is4In6(), "%v is not an IPv6 address", addr)
+			assert.True(t, check(addr), "%v is not a %v address", addr, class)
+			if class == PublicAddress {
+				assert.False(t, prefixContains(ipv6Reserved, addr), "%v is reserved", addr)
+			}
+		}
+	}
+}
+
+func TestIPv4MappedIPv6(t *testing.T) {
+	r := FromSeed(1234)
+	addr := r.IPv4MappedIPv6(PrivateAddress)
+	assert.True(t, addr.Is4In6(), "%v is not an IPv4-mapped address", addr)
+	assert.True(t, addr.Unmap().IsPrivate(), "%v is not private", addr)
+}
+
+func TestIPInPrefix(t *testing.T) {
+	r := FromSeed(1234)
+	for _, cidr := range []string{"10.20.0.0/16", "192.168.1.128/25", "10.1.2.3/32", "2001:db8:1::/48", "fe80::/10", "0.0.0.0/0"} {
+		prefix := netip.MustParsePrefix(cidr)
+		for i := 0; i < 100; i++ {
+			addr := r.IPInPrefix(prefix)
+			assert.True(t, prefix.Contains(addr), "%v not in %v", addr, prefix)
+		}
+	}
+	assert.False(t, r.IPInPrefix(netip.Prefix{}).IsValid())
+
+	// The address bits of a non-canonical prefix beyond its length are randomized too.
+	hosts := map[netip.Addr]bool{}
+	for i := 0; i < 100; i++ {
+		hosts[r.IPInPrefix(netip.MustParsePrefix("10.0.0.7/24"))] = true
+	}
+	assert.Greater(t, len(hosts), 50)
+}
+
+func TestIPPrefix(t *testing.T) {
+	r := FromSeed(1234)
+	prefix, err := r.IPv4Prefix(PrivateAddress, 24)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 24, prefix.Bits())
+		assert.Equal(t, prefix, prefix.Masked())
+		assert.True(t, prefix.Addr().IsPrivate(), "%v is not private", prefix)
+	}
+	prefix, err = r.IPv6Prefix(PublicAddress, 48)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 48, prefix.Bits())
+		assert.True(t, prefix.Addr().IsGlobalUnicast(), "%v is not global", prefix)
+	}
+	_, err = r.IPv4Prefix(AnyAddress, 33)
+	assert.Error(t, err)
+}
+
+func TestSubnetOf(t *testing.T) {
+	r := FromSeed(1234)
+	parent := netip.MustParsePrefix("10.0.0.0/8")
+	subnets := map[netip.Prefix]bool{}
+	for i := 0; i < 100; i++ {
+		subnet, err := r.SubnetOf(parent, 24)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.Equal(t, 24, subnet.Bits())
+		assert.True(t, parent.Contains(subnet.Addr()), "%v not in %v", subnet, parent)
+		subnets[subnet] = true
+	}
+	assert.Greater(t, len(subnets), 90)
+
+	same, err := r.SubnetOf(parent, 8)
+	assert.NoError(t, err)
+	assert.Equal(t, parent, same)
+
+	_, err = r.SubnetOf(parent, 4)
+	assert.Error(t, err)
+	_, err = r.SubnetOf(parent, 33)
+	assert.Error(t, err)
+	_, err = r.SubnetOf(netip.Prefix{}, 24)
+	assert.Error(t, err)
+}
@@ -0,0 +1,293 @@
+package randomdata
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+)
+
+// Format obtained from:
+// * https://www.rfc-editor.org/rfc/rfc7519 (JSON Web Token)
+// * https://www.rfc-editor.org/rfc/rfc7518 (HS256, RS256 and ES256)
+// * https://www.rfc-editor.org/rfc/rfc8037 (EdDSA)
+
+// JWTAlgorithm is the algorithm signing a JSON Web Token.
+type JWTAlgorithm int
+
+const (
+	// HS256 is HMAC with SHA-256, the key being a []byte secret.
+	HS256 JWTAlgorithm = iota
+	// RS256 is RSASSA-PKCS1-v1_5 with SHA-256, the key being an *rsa.PrivateKey.
+	RS256
+	// ES256 is ECDSA on P-256 with SHA-256, the key being an *ecdsa.PrivateKey.
+	ES256
+	// EdDSA is Ed25519, the key being an ed25519.PrivateKey.
+	EdDSA
+)
+
+// String returns the "alg" header parameter of the algorithm.
+func (a JWTAlgorithm) String() string {
+	switch a {
+	case HS256:
+		return "HS256"
+	case RS256:
+		return "RS256"
+	case ES256:
+		return "ES256"
+	case EdDSA:
+		return "EdDSA"
+	}
+	return "unknown"
+}
+
+// JWTDefect is a deliberate flaw of a JSON Web Token, which a verifier must reject.
+type JWTDefect int
+
+const (
+	// ValidJWT has no defect.
+	ValidJWT JWTDefect = iota
+	// ExpiredJWT has an expiration time before the current time.
+	ExpiredJWT
+	// UnsignedJWT uses the "none" algorithm and has an empty signature.
+	UnsignedJWT
+	// BadSignatureJWT has a signature which does not match its content.
+	BadSignatureJWT
+)
+
+// String returns the name of the defect.
+func (d JWTDefect) String() string {
+	switch d {
+	case ValidJWT:
+		return "valid"
+	case ExpiredJWT:
+		return "expired"
+	case UnsignedJWT:
+		return "unsigned"
+	case BadSignatureJWT:
+		return "bad signature"
+	}
+	return "unknown"
+}
+
+// JWTOptions drives the generation of a JSON Web Token.
+type JWTOptions struct {
+	// Algorithm signs the token. The zero value means HS256.
+	Algorithm JWTAlgorithm
+	// Key is the signing key, whose type must match the algorithm.
+	// The zero value means a key generated from the random source: a 256-bit secret, a 2048-bit RSA key,
+	// a P-256 key or an Ed25519 key. Generating RSA keys is slow, supply one to sign many tokens.
+	Key crypto.PrivateKey
+	// Profile is the subject of the token. The zero value means a generated profile.
+	Profile *Profile
+	// Issuer is the "iss" claim. The zero value means an authorization server of a random domain.
+	Issuer string
+	// Audience is the "aud" claim. The zero value means an API of the issuer's domain.
+	Audience string
+	// Now is the current time, "iat" and "nbf" being at most half the lifetime before it. The zero value means time.Now().
+	Now time.Time
+	// Lifetime is the time between "iat" and "exp". The zero value means one hour.
+	Lifetime time.Duration
+	// Defect is the flaw of the token. The zero value means a valid token.
+	Defect JWTDefect
+}
+
+// JWTClaims are the claims of a generated JSON Web Token.
+type JWTClaims struct {
+	Issuer    string
+	Subject   string
+	Audience  string
+	IssuedAt  time.Time
+	NotBefore time.Time
+	Expiry    time.Time
+	ID        string
+	Name      string
+	Email     string
+}
+
+// JWT is a generated JSON Web Token along with its claims and keys.
+type JWT struct {
+	Token     string
+	Algorithm JWTAlgorithm
+	Defect    JWTDefect
+	Claims    JWTClaims
+	// SigningKey is the key which signed the token, or would have signed an UnsignedJWT.
+	SigningKey crypto.PrivateKey
+	// VerificationKey is the key checking the signature: the []byte secret for HS256, the public key otherwise.
+	VerificationKey crypto.PublicKey
+}
+
+// JWT returns a signed JSON Web Token with coherent standard claims, whose subject is a profile, following the
+// supplied options. It returns an error if the algorithm is unknown or the key does not match it.
+//
+// Tokens signed with HS256 or EdDSA only depend on the random source. RSA and ECDSA keys, as well as ECDSA
+// signatures, are generated by crypto packages which mix in their own entropy, but they take a fixed number of
+// values from the random source, so that the values generated afterwards still replay with the seed.
+func (r *Rand) JWT(opts JWTOptions) (JWT, error) {
+	key, err := r.jwtKey(opts.Algorithm, opts.Key)
+	if err != nil {
+		return JWT{}, err
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	lifetime := opts.Lifetime
+	if lifetime <= 0 {
+		lifetime = time.Hour
+	}
+	profile := opts.Profile
+	if profile == nil {
+		profile = r.GenerateProfile(RandomGender)
+	}
+	domain := r.DomainName()
+	issuer, audience := opts.Issuer, opts.Audience
+	if issuer == "" {
+		issuer = "https://" + r.StringFrom([]string{"auth", "login", "id", "sso"}) + "." + domain + "/"
+	}
+	if audience == "" {
+		audience = "https://api." + domain
+	}
+
+	// A valid token was issued within the first half of its lifetime, an expired one up to a day after it ended.
+	issued := now.Add(-time.Duration(r.Float64() * float64(lifetime/2)))
+	if opts.Defect == ExpiredJWT {
+		issued = now.Add(-lifetime - time.Second - time.Duration(r.Float64()*float64(24*time.Hour)))
+	}
+	issued = issued.Truncate(time.Second)
+	claims := JWTClaims{
+		Issuer:    issuer,
+		Subject:   profile.Login.Username,
+		Audience:  audience,
+		IssuedAt:  issued,
+		NotBefore: issued,
+		Expiry:    issued.Add(lifetime),
+		ID:        r.UUIDv4(),
+		Name:      profile.Name.First + " " + profile.Name.Last,
+		Email:     profile.Email,
+	}
+
+	alg := opts.Algorithm.String()
+	if opts.Defect == UnsignedJWT {
+		alg = "none"
+	}
+	header, _ := json.Marshal(struct {
+		Algorithm string `json:"alg"`
+		Type      string `json:"typ"`
+	}{alg, "JWT"})
+	payload, _ := json.Marshal(struct {
+		Issuer    string `json:"iss"`
+		Subject   string `json:"sub"`
+		Audience  string `json:"aud"`
+		IssuedAt  int64  `json:"iat"`
+		NotBefore int64  `json:"nbf"`
+		Expiry    int64  `json:"exp"`
+		ID        string `json:"jti"`
+		Name      string `json:"name"`
+		Email     string `json:"email"`
+	}{claims.Issuer, claims.Subject, claims.Audience, claims.IssuedAt.Unix(), claims.NotBefore.Unix(), claims.Expiry.Unix(),
+		claims.ID, claims.Name, claims.Email})
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	var signature []byte
+	if opts.Defect != UnsignedJWT {
+		if signature, err = r.jwtSign(opts.Algorithm, key, signingInput); err != nil {
+			return JWT{}, err
+		}
+		if opts.Defect == BadSignatureJWT {
+			signature[r.Intn(len(signature))] ^= byte(1 << uint(r.Intn(8)))
+		}
+	}
+	token := JWT{
+		Token:      signingInput + "." + base64.RawURLEncoding.EncodeToString(signature),
+		Algorithm:  opts.Algorithm,
+		Defect:     opts.Defect,
+		Claims:     claims,
+		SigningKey: key,
+	}
+	switch key := key.(type) {
+	case []byte:
+		token.VerificationKey = key
+	case crypto.Signer:
+		token.VerificationKey = key.Public()
+	}
+	return token, nil
+}
+
+// jwtKey checks that the key matches the algorithm, generating one if it is nil.
+func (r *Rand) jwtKey(algorithm JWTAlgorithm, key crypto.PrivateKey) (crypto.PrivateKey, error) {
+	var ok bool
+	switch algorithm {
+	case HS256:
+		if key == nil {
+			return r.randomBytes(32), nil
+		}
+		var secret []byte
+		secret, ok = key.([]byte)
+		ok = ok && len(secret) > 0
+	case RS256:
+		if key == nil {
+			return rsa.GenerateKey(r.cryptoReader(), 2048)
+		}
+		_, ok = key.(*rsa.PrivateKey)
+	case ES256:
+		if key == nil {
+			return ecdsa.GenerateKey(elliptic.P256(), r.cryptoReader())
+		}
+		var private *ecdsa.PrivateKey
+		private, ok = key.(*ecdsa.PrivateKey)
+		ok = ok && private.Curve == elliptic.P256()
+	case EdDSA:
+		if key == nil {
+			return ed25519.NewKeyFromSeed(r.randomBytes(ed25519.SeedSize)), nil
+		}
+		var private ed25519.PrivateKey
+		private, ok = key.(ed25519.PrivateKey)
+		ok = ok && len(private) == ed25519.PrivateKeySize
+	default:
+		return nil, fmt.Errorf("randomdata: unknown JWT algorithm %v", algorithm)
+	}
+	if !ok {
+		return nil, fmt.Errorf("randomdata: invalid %v key of type %T", algorithm, key)
+	}
+	return key, nil
+}
+
+// jwtSign returns the signature of the signing input, ECDSA signatures being the concatenation of r and s.
+func (r *Rand) jwtSign(algorithm JWTAlgorithm, key crypto.PrivateKey, signingInput string) ([]byte, error) {
+	digest := sha256.Sum256([]byte(signingInput))
+	switch algorithm {
+	case HS256:
+		mac := hmac.New(sha256.New, key.([]byte))
+		mac.Write([]byte(signingInput))
+		return mac.Sum(nil), nil
+	case RS256:
+		return rsa.SignPKCS1v15(nil, key.(*rsa.PrivateKey), crypto.SHA256, digest[:])
+	case ES256:
+		sigR, sigS, err := ecdsa.Sign(r.cryptoReader(), key.(*ecdsa.PrivateKey), digest[:])
+		if err != nil {
+			return nil, err
+		}
+		signature := make([]byte, 64)
+		sigR.FillBytes(signature[:32])
+		sigS.FillBytes(signature[32:])
+		return signature, nil
+	}
+	return ed25519.Sign(key.(ed25519.PrivateKey), []byte(signingInput)), nil
+}
+
+// cryptoReader returns a reader for the crypto packages, seeded once from the random source. They read a varying
+// number of bytes, see crypto/internal/randutil.MaybeReadByte, which would shift the values generated afterwards.
+func (r *Rand) cryptoReader() io.Reader {
+	return rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(r.randomBytes(8)))))
+}
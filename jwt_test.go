@@ -0,0 +1,147 @@
+package randomdata
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWT(t *testing.T) {
+	r := FromSeed(1234)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	for _, algorithm := range []JWTAlgorithm{HS256, RS256, ES256, EdDSA} {
+		var key crypto.PrivateKey
+		if algorithm == RS256 {
+			key = rsaKey
+		}
+		for _, defect := range []JWTDefect{ValidJWT, ExpiredJWT, UnsignedJWT, BadSignatureJWT} {
+			token, err := r.JWT(JWTOptions{Algorithm: algorithm, Key: key, Now: now, Defect: defect})
+			if !assert.NoError(t, err) {
+				continue
+			}
+			assert.Equal(t, algorithm, token.Algorithm)
+			assert.Equal(t, defect, token.Defect)
+			header, claims, ok := verifyJWT(t, token.Token, token.VerificationKey)
+			assert.Equal(t, defect != UnsignedJWT && defect != BadSignatureJWT, ok, "%v %v token", algorithm, defect)
+			if defect == UnsignedJWT {
+				assert.Equal(t, "none", header["alg"])
+				assert.True(t, strings.HasSuffix(token.Token, "."))
+			} else {
+				assert.Equal(t, algorithm.String(), header["alg"])
+			}
+
+			assert.Equal(t, token.Claims.Subject, claims["sub"])
+			assert.Equal(t, token.Claims.ID, claims["jti"])
+			assert.Regexp(t, `^https://\w+\.[a-z0-9.-]+/$`, claims["iss"])
+			assert.Equal(t, "https://api."+strings.SplitN(token.Claims.Issuer, ".", 2)[1], claims["aud"].(string)+"/")
+			assert.NotEmpty(t, claims["sub"])
+			assert.Contains(t, claims["email"], "@")
+			iat, nbf, exp := int64(claims["iat"].(float64)), int64(claims["nbf"].(float64)), int64(claims["exp"].(float64))
+			assert.Equal(t, token.Claims.IssuedAt.Unix(), iat)
+			assert.Equal(t, iat, nbf)
+			assert.Equal(t, iat+3600, exp)
+			assert.LessOrEqual(t, nbf, now.Unix())
+			assert.Equal(t, defect == ExpiredJWT, exp <= now.Unix(), "%v %v token expires at %v", algorithm, defect, exp)
+		}
+	}
+}
+
+func TestJWTOptions(t *testing.T) {
+	r := FromSeed(1234)
+	profile := r.GenerateProfile(Female)
+	secret := []byte("s3cr3t")
+	token, err := r.JWT(JWTOptions{Key: secret, Profile: profile, Issuer: "https://issuer.example/", Audience: "orders", Lifetime: 5 * time.Minute})
+	if assert.NoError(t, err) {
+		assert.Equal(t, secret, token.VerificationKey)
+		assert.Equal(t, profile.Login.Username, token.Claims.Subject)
+		assert.Equal(t, profile.Email, token.Claims.Email)
+		assert.Equal(t, "https://issuer.example/", token.Claims.Issuer)
+		assert.Equal(t, "orders", token.Claims.Audience)
+		assert.Equal(t, 5*time.Minute, token.Claims.Expiry.Sub(token.Claims.IssuedAt))
+		assert.True(t, token.Claims.Expiry.After(time.Now()))
+		_, _, ok := verifyJWT(t, token.Token, secret)
+		assert.True(t, ok)
+	}
+
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	p384Key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	for _, opts := range []JWTOptions{
+		{Algorithm: HS256, Key: ecKey},
+		{Algorithm: HS256, Key: []byte{}},
+		{Algorithm: RS256, Key: edKey},
+		{Algorithm: ES256, Key: p384Key},
+		{Algorithm: EdDSA, Key: secret},
+		{Algorithm: JWTAlgorithm(-1)},
+	} {
+		_, err := r.JWT(opts)
+		assert.Error(t, err, "%v with %T", opts.Algorithm, opts.Key)
+	}
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, algorithm := range []JWTAlgorithm{HS256, EdDSA} {
+		first, _ := FromSeed(42).JWT(JWTOptions{Algorithm: algorithm, Now: now})
+		second, _ := FromSeed(42).JWT(JWTOptions{Algorithm: algorithm, Now: now})
+		assert.Equal(t, first.Token, second.Token)
+	}
+}
+
+func TestJWTReplay(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, algorithm := range []JWTAlgorithm{RS256, ES256} {
+		first, second := FromSeed(42), FromSeed(42)
+		firstToken, err := first.JWT(JWTOptions{Algorithm: algorithm, Now: now})
+		assert.NoError(t, err)
+		secondToken, err := second.JWT(JWTOptions{Algorithm: algorithm, Now: now})
+		assert.NoError(t, err)
+		assert.Equal(t, firstToken.Claims, secondToken.Claims, "%v claims", algorithm)
+		assert.Equal(t, first.Intn(1<<30), second.Intn(1<<30), "%v replay", algorithm)
+	}
+}
+
+// verifyJWT decodes a token and tells whether its signature is valid.
+func verifyJWT(t *testing.T, token string, key crypto.PublicKey) (header, claims map[string]interface{}, ok bool) {
+	parts := strings.Split(token, ".")
+	if !assert.Len(t, parts, 3) {
+		return nil, nil, false
+	}
+	for i, object := range []*map[string]interface{}{&header, &claims} {
+		b, err := base64.RawURLEncoding.DecodeString(parts[i])
+		if assert.NoError(t, err) {
+			assert.NoError(t, json.Unmarshal(b, object))
+		}
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	assert.NoError(t, err)
+	signingInput := []byte(parts[0] + "." + parts[1])
+	digest := sha256.Sum256(signingInput)
+	switch header["alg"] {
+	case "HS256":
+		mac := hmac.New(sha256.New, key.([]byte))
+		mac.Write(signingInput)
+		ok = hmac.Equal(signature, mac.Sum(nil))
+	case "RS256":
+		ok = rsa.VerifyPKCS1v15(key.(*rsa.PublicKey), crypto.SHA256, digest[:], signature) == nil
+	case "ES256":
+		ok = len(signature) == 64 && ecdsa.Verify(key.(*ecdsa.PublicKey), digest[:],
+			new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:]))
+	case "EdDSA":
+		ok = ed25519.Verify(key.(ed25519.PublicKey), signingInput, signature)
+	}
+	return header, claims, ok
+}
@@ -0,0 +1,196 @@
+package randomdata
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+)
+
+// Organizationally unique identifiers obtained from the IEEE MA-L registry:
+// * https://standards-oui.ieee.org/oui/oui.txt
+var macVendors = map[string][]string{
+	"Apple":        {"00:03:93", "00:0A:95", "00:17:F2", "00:1B:63", "00:1E:C2", "00:25:00", "28:CF:E9", "3C:07:54", "A4:5E:60", "AC:BC:32", "F0:18:98"},
+	"Arista":       {"00:1C:73", "28:99:3A"},
+	"Broadcom":     {"00:10:18"},
+	"Cisco":        {"00:00:0C", "00:1B:54", "00:1E:13", "00:25:45", "00:40:96", "58:97:1E"},
+	"Dell":         {"00:14:22", "00:1E:4F", "18:03:73", "B8:2A:72", "F8:B1:56"},
+	"Espressif":    {"24:0A:C4", "30:AE:A4", "84:CC:A8"},
+	"Google":       {"00:1A:11", "3C:5A:B4", "F4:F5:D8"},
+	"HP":           {"00:1F:29", "00:25:B3", "3C:D9:2B", "9C:8E:99"},
+	"Huawei":       {"00:18:82", "00:E0:FC", "28:6E:D4"},
+	"Intel":        {"00:15:17", "00:1B:21", "00:1E:67", "3C:97:0E", "A0:36:9F"},
+	"Juniper":      {"00:05:85", "00:19:E2", "2C:6B:F5"},
+	"Microsoft":    {"00:03:FF", "00:15:5D", "00:50:F2"},
+	"Netgear":      {"00:09:5B", "00:14:6C", "20:4E:7F"},
+	"Nintendo":     {"00:09:BF", "00:17:AB", "00:1F:32"},
+	"Raspberry Pi": {"B8:27:EB", "DC:A6:32", "E4:5F:01"},
+	"Realtek":      {"00:E0:4C"},
+	"Samsung":      {"00:12:47", "00:15:99", "5C:0A:5B", "8C:77:12"},
+	"Super Micro":  {"00:25:90", "0C:C4:7A", "AC:1F:6B"},
+	"TP-Link":      {"14:CC:20", "50:C7:BF", "F4:F2:6D"},
+	"Ubiquiti":     {"00:15:6D", "04:18:D6", "24:A4:3C", "80:2A:A8"},
+	"VMware":       {"00:05:69", "00:0C:29", "00:50:56"},
+	"VirtualBox":   {"08:00:27"},
+	"Xen":          {"00:16:3E"},
+}
+
+const (
+	// macMulticast is the I/G bit of the first octet: set for group addresses.
+	macMulticast = 0x01
+	// macLocal is the U/L bit of the first octet: set for locally administered addresses.
+	macLocal = 0x02
+)
+
+// MAC is a 48-bit IEEE 802 MAC address.
+type MAC [6]byte
+
+// MacOptions drives the generation of a MAC address.
+type MacOptions struct {
+	// Vendor is the manufacturer whose OUI starts the address, see SupportedMacVendors.
+	// The zero value means any of them. It is ignored for locally administered addresses.
+	Vendor string
+	// Multicast sets the group bit. The zero value gives a unicast address.
+	Multicast bool
+	// Local gives a locally administered address, made of random bits, instead of one starting with a vendor OUI.
+	Local bool
+}
+
+// MAC returns a random MAC address following the supplied options.
+// If the vendor is not supported it will return the zero MAC.
+func (r *Rand) MAC(opts MacOptions) MAC {
+	var mac MAC
+	if opts.Local {
+		for i := range mac {
+			mac[i] = byte(r.Intn(256))
+		}
+		mac[0] = mac[0]&^macMulticast | macLocal
+	} else {
+		ouis := macVendorOUIs(opts.Vendor)
+		if len(ouis) == 0 {
+			return MAC{}
+		}
+		oui := r.StringFrom(ouis)
+		fmt.Sscanf(oui, "%02X:%02X:%02X", &mac[0], &mac[1], &mac[2])
+		for i := 3; i < len(mac); i++ {
+			mac[i] = byte(r.Intn(256))
+		}
+	}
+	if opts.Multicast {
+		mac[0] |= macMulticast
+	}
+	return mac
+}
+
+// MacAddressFor returns a random universally administered unicast MAC address of the supplied vendor, e.g. "Cisco".
+// The vendor is matched regardless of case.
+// If the vendor is not supported it will return the zero MAC.
+func (r *Rand) MacAddressFor(vendor string) MAC {
+	if vendor == "" {
+		return MAC{}
+	}
+	return r.MAC(MacOptions{Vendor: vendor})
+}
+
+// SupportedMacVendors returns the sorted names of the vendors known by MacAddressFor.
+func SupportedMacVendors() []string {
+	vendors := make([]string, 0, len(macVendors))
+	for vendor := range macVendors {
+		vendors = append(vendors, vendor)
+	}
+	sort.Strings(vendors)
+	return vendors
+}
+
+// macVendorOUIs returns the OUIs of a vendor, or of all the vendors when the name is empty.
+func macVendorOUIs(vendor string) []string {
+	var ouis []string
+	for _, name := range SupportedMacVendors() {
+		if vendor == "" || strings.EqualFold(name, vendor) {
+			ouis = append(ouis, macVendors[name]...)
+		}
+	}
+	return ouis
+}
+
+// Vendor returns the name of the vendor owning the OUI of the address, or an empty string if it is not known.
+func (m MAC) Vendor() string {
+	oui := fmt.Sprintf("%02X:%02X:%02X", m[0], m[1], m[2])
+	for vendor, ouis := range macVendors {
+		for _, candidate := range ouis {
+			if candidate == oui {
+				return vendor
+			}
+		}
+	}
+	return ""
+}
+
+// IsMulticast tells whether the group bit of the address is set.
+func (m MAC) IsMulticast() bool {
+	return m[0]&macMulticast != 0
+}
+
+// IsLocal tells whether the address is locally administered.
+func (m MAC) IsLocal() bool {
+	return m[0]&macLocal != 0
+}
+
+// String returns the address as lowercase colon-separated octets, e.g. "00:1b:54:0a:3f:c2".
+func (m MAC) String() string {
+	return m.join(":", 1, "%02x")
+}
+
+// Dashed returns the address as uppercase dash-separated octets, as written by IEEE and Windows,
+// e.g. "00-1B-54-0A-3F-C2".
+func (m MAC) Dashed() string {
+	return m.join("-", 1, "%02X")
+}
+
+// Cisco returns the address as dot-separated groups of four hexadecimal digits, e.g. "001b.540a.3fc2".
+func (m MAC) Cisco() string {
+	return m.join(".", 2, "%02x")
+}
+
+// EUI64 returns the 64-bit extended unique identifier derived from the address by inserting FF:FE
+// between the OUI and the rest, e.g. "00:1b:54:ff:fe:0a:3f:c2".
+func (m MAC) EUI64() string {
+	eui := m.eui64()
+	parts := make([]string, len(eui))
+	for i, octet := range eui {
+		parts[i] = fmt.Sprintf("%02x", octet)
+	}
+	return strings.Join(parts, ":")
+}
+
+// LinkLocalIPv6 returns the IPv6 link-local address built from the modified EUI-64 of the address (RFC 4291),
+// e.g. fe80::21b:54ff:fe0a:3fc2.
+func (m MAC) LinkLocalIPv6() netip.Addr {
+	addr := [16]byte{0: 0xfe, 1: 0x80}
+	eui := m.eui64()
+	eui[0] ^= macLocal
+	copy(addr[8:], eui[:])
+	return netip.AddrFrom16(addr)
+}
+
+func (m MAC) eui64() [8]byte {
+	return [8]byte{m[0], m[1], m[2], 0xff, 0xfe, m[3], m[4], m[5]}
+}
+
+// HardwareAddr returns the address as a net.HardwareAddr.
+func (m MAC) HardwareAddr() net.HardwareAddr {
+	return net.HardwareAddr(m[:])
+}
+
+// join formats the octets with the supplied verb, putting sep every group octets.
+func (m MAC) join(sep string, group int, verb string) string {
+	var b strings.Builder
+	for i, octet := range m {
+		if i > 0 && i%group == 0 {
+			b.WriteString(sep)
+		}
+		fmt.Fprintf(&b, verb, octet)
+	}
+	return b.String()
+}
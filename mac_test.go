@@ -0,0 +1,88 @@
+package randomdata
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMacAddressFor(t *testing.T) {
+	r := FromSeed(1234)
+	for _, vendor := range SupportedMacVendors() {
+		for i := 0; i < 20; i++ {
+			mac := r.MacAddressFor(vendor)
+			assert.Equal(t, vendor, mac.Vendor(), "%v", mac)
+			assert.False(t, mac.IsMulticast(), "%v is multicast", mac)
+			assert.False(t, mac.IsLocal(), "%v is locally administered", mac)
+		}
+	}
+	assert.Equal(t, "Cisco", r.MacAddressFor("cisco").Vendor())
+	assert.Equal(t, MAC{}, r.MacAddressFor("Acme"))
+	assert.Equal(t, MAC{}, r.MacAddressFor(""))
+}
+
+func TestMACOptions(t *testing.T) {
+	r := FromSeed(1234)
+	for _, opts := range []MacOptions{{}, {Multicast: true}, {Local: true}, {Local: true, Multicast: true}, {Vendor: "Intel", Multicast: true}} {
+		for i := 0; i < 100; i++ {
+			mac := r.MAC(opts)
+			assert.Equal(t, opts.Multicast, mac.IsMulticast(), "%v with %+v", mac, opts)
+			assert.Equal(t, opts.Local, mac.IsLocal(), "%v with %+v", mac, opts)
+			if opts.Vendor != "" {
+				assert.Equal(t, opts.Vendor, MAC{mac[0] &^ macMulticast, mac[1], mac[2]}.Vendor())
+			}
+		}
+	}
+}
+
+func TestMacAddressUnicast(t *testing.T) {
+	r := FromSeed(1234)
+	for i := 0; i < 1000; i++ {
+		hw, err := net.ParseMAC(r.MacAddress())
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.Zero(t, hw[0]&macMulticast, "%v is multicast", hw)
+		assert.NotEmpty(t, MAC{hw[0], hw[1], hw[2]}.Vendor(), "%v has no known vendor", hw)
+	}
+}
+
+func TestMACFormats(t *testing.T) {
+	mac := MAC{0x00, 0x1b, 0x54, 0x0a, 0x3f, 0xc2}
+	assert.Equal(t, "00:1b:54:0a:3f:c2", mac.String())
+	assert.Equal(t, "00-1B-54-0A-3F-C2", mac.Dashed())
+	assert.Equal(t, "001b.540a.3fc2", mac.Cisco())
+	assert.Equal(t, "00:1b:54:ff:fe:0a:3f:c2", mac.EUI64())
+	assert.Equal(t, "fe80::21b:54ff:fe0a:3fc2", mac.LinkLocalIPv6().String())
+	assert.Equal(t, net.HardwareAddr{0x00, 0x1b, 0x54, 0x0a, 0x3f, 0xc2}, mac.HardwareAddr())
+
+	r := FromSeed(1234)
+	for i := 0; i < 100; i++ {
+		mac := r.MAC(MacOptions{Local: true})
+		for _, s := range []string{mac.String(), mac.Dashed(), mac.Cisco()} {
+			hw, err := net.ParseMAC(s)
+			if assert.NoError(t, err, s) {
+				assert.Equal(t, mac.HardwareAddr(), hw, s)
+			}
+		}
+		assert.Regexp(t, regexp.MustCompile(`^([0-9a-f]{2}:){7}[0-9a-f]{2}$`), mac.EUI64())
+		assert.True(t, mac.LinkLocalIPv6().IsLinkLocalUnicast())
+	}
+}
+
+func TestMacVendorOUIs(t *testing.T) {
+	seen := map[string]string{}
+	for vendor, ouis := range macVendors {
+		for _, oui := range ouis {
+			assert.Regexp(t, `^[0-9A-F]{2}(:[0-9A-F]{2}){2}$`, oui)
+			assert.Empty(t, seen[oui], "%s belongs to %s and %s", oui, seen[oui], vendor)
+			seen[oui] = vendor
+			first, err := strconv.ParseUint(oui[:2], 16, 8)
+			assert.NoError(t, err)
+			assert.Zero(t, first&(macMulticast|macLocal), "%s of %s is not a universal unicast OUI", oui, vendor)
+		}
+	}
+}
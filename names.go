@@ -0,0 +1,123 @@
+package randomdata
+
+import (
+	"strings"
+)
+
+// PersonNameOptions configures the names generated by PersonName.
+// The zero value yields a plain "First Last" name of random gender.
+type PersonNameOptions struct {
+	// Gender decides the gender of the title, first and middle names. The zero value means Male or Female at random.
+	Gender *Gender
+	// MaxMiddleNames is the maximum number of middle names, a random count between zero and this value is used.
+	MaxMiddleNames int
+	// GenerationalSuffixRate is the probability of a generational suffix such as Jr. or III.
+	GenerationalSuffixRate float64
+	// ProfessionalSuffixRate is the probability of a professional suffix such as PhD or MD.
+	ProfessionalSuffixRate float64
+	// CompoundSurnameRate is the probability of a hyphenated or multi-part surname such as "van der Berg".
+	CompoundSurnameRate float64
+}
+
+// PersonName is a structured personal name.
+type PersonName struct {
+	Title                string   `json:"title"`
+	First                string   `json:"first"`
+	Middle               []string `json:"middle,omitempty"`
+	Particle             string   `json:"particle,omitempty"` // e.g. "van der", "de la"
+	Last                 string   `json:"last"`               // may be hyphenated
+	GenerationalSuffix   string   `json:"generationalSuffix,omitempty"`
+	ProfessionalSuffixes []string `json:"professionalSuffixes,omitempty"`
+	Nickname             string   `json:"nickname,omitempty"` // empty if the first name has no common nickname
+}
+
+// PersonName returns a random structured name.
+func (r *Rand) PersonName(opts PersonNameOptions) PersonName {
+	gender := RandomGender
+	if opts.Gender != nil {
+		gender = *opts.Gender
+	}
+	if gender != Male && gender != Female && gender != NonBinary {
+		gender = Gender(r.Intn(2))
+	}
+	name := PersonName{
+		Title: r.Title(gender),
+		First: r.FirstName(gender),
+		Last:  r.LastName(),
+	}
+	if opts.MaxMiddleNames > 0 {
+		for i := r.Intn(opts.MaxMiddleNames + 1); i > 0; i-- {
+			middle := r.FirstName(gender)
+			for middle == name.First {
+				middle = r.FirstName(gender)
+			}
+			name.Middle = append(name.Middle, middle)
+		}
+	}
+	if r.Float64() < opts.CompoundSurnameRate {
+		if r.Boolean() {
+			name.Particle = r.StringFrom(jsonData.SurnameParticles)
+		} else {
+			last := r.LastName()
+			for last == name.Last {
+				last = r.LastName()
+			}
+			name.Last += "-" + last
+		}
+	}
+	if r.Float64() < opts.GenerationalSuffixRate {
+		name.GenerationalSuffix = r.StringFrom(jsonData.GenerationalSuffixes)
+	}
+	if r.Float64() < opts.ProfessionalSuffixRate {
+		name.ProfessionalSuffixes = []string{r.StringFrom(jsonData.ProfessionalSuffixes)}
+	}
+	name.Nickname = r.StringFrom(jsonData.Nicknames[name.First])
+	return name
+}
+
+// Surname returns the surname including its particle, e.g. "van der Berg".
+func (n PersonName) Surname() string {
+	return joinNonEmpty(" ", n.Particle, n.Last)
+}
+
+// String returns the name as it is usually written: given names, surname and generational suffix.
+func (n PersonName) String() string {
+	return joinNonEmpty(" ", n.First, strings.Join(n.Middle, " "), n.Surname(), n.GenerationalSuffix)
+}
+
+// Formal returns the name with its title and professional suffixes, e.g. "Mr John Paul Smith Jr., PhD".
+func (n PersonName) Formal() string {
+	formal := joinNonEmpty(" ", n.Title, n.String())
+	for _, suffix := range n.ProfessionalSuffixes {
+		formal += ", " + suffix
+	}
+	return formal
+}
+
+// Initials returns the initials of the given names and the surname, e.g. "J.P.S.".
+// Surname particles are ignored.
+func (n PersonName) Initials() string {
+	var initials strings.Builder
+	for _, part := range append(append([]string{n.First}, n.Middle...), n.Last) {
+		if part == "" {
+			continue
+		}
+		initials.WriteString(strings.ToUpper(string([]rune(part)[0])) + ".")
+	}
+	return initials.String()
+}
+
+// LastFirst returns the name in sorted order, e.g. "van der Berg, John Paul, Jr.".
+func (n PersonName) LastFirst() string {
+	return joinNonEmpty(", ", n.Surname(), joinNonEmpty(" ", n.First, strings.Join(n.Middle, " ")), n.GenerationalSuffix)
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	nonEmpty := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, sep)
+}
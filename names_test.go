@@ -0,0 +1,91 @@
+package randomdata
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonName(t *testing.T) {
+	r := FromSeed(1234)
+
+	t.Run("should generate a plain name by default", func(t *testing.T) {
+		female := Female
+		name := r.PersonName(PersonNameOptions{Gender: &female})
+		assert.Contains(t, jsonData.FirstNamesFemale, name.First)
+		assert.Contains(t, jsonData.LastNames, name.Last)
+		assert.Contains(t, jsonData.FemaleTitles, name.Title)
+		assert.Empty(t, name.Middle)
+		assert.Empty(t, name.Particle)
+		assert.Empty(t, name.GenerationalSuffix)
+		assert.Empty(t, name.ProfessionalSuffixes)
+		assert.Equal(t, name.First+" "+name.Last, name.String())
+	})
+
+	t.Run("should generate all the optional parts", func(t *testing.T) {
+		male := Male
+		opts := PersonNameOptions{
+			Gender:                 &male,
+			MaxMiddleNames:         2,
+			GenerationalSuffixRate: 1,
+			ProfessionalSuffixRate: 1,
+			CompoundSurnameRate:    1,
+		}
+		for i := 0; i < 100; i++ {
+			name := r.PersonName(opts)
+			assert.LessOrEqual(t, len(name.Middle), 2)
+			for _, middle := range name.Middle {
+				assert.Contains(t, jsonData.FirstNamesMale, middle)
+				assert.NotEqual(t, name.First, middle)
+			}
+			assert.True(t, name.Particle != "" || strings.Contains(name.Last, "-"),
+				"expected a compound surname, got %q", name.Surname())
+			assert.Contains(t, jsonData.GenerationalSuffixes, name.GenerationalSuffix)
+			assert.Len(t, name.ProfessionalSuffixes, 1)
+			if nicknames, ok := jsonData.Nicknames[name.First]; ok {
+				assert.Contains(t, nicknames, name.Nickname)
+			} else {
+				assert.Empty(t, name.Nickname)
+			}
+		}
+	})
+	t.Run("should pick the gender at random by default", func(t *testing.T) {
+		titles := map[string]bool{}
+		for i := 0; i < 200; i++ {
+			name := r.PersonName(PersonNameOptions{})
+			switch {
+			case containsString(jsonData.MaleTitles, name.Title):
+				assert.Contains(t, jsonData.FirstNamesMale, name.First)
+				titles["male"] = true
+			case containsString(jsonData.FemaleTitles, name.Title):
+				assert.Contains(t, jsonData.FirstNamesFemale, name.First)
+				titles["female"] = true
+			}
+		}
+		assert.Equal(t, map[string]bool{"male": true, "female": true}, titles)
+	})
+}
+
+func TestPersonNameFormatting(t *testing.T) {
+	name := PersonName{
+		Title:                "Dr",
+		First:                "John",
+		Middle:               []string{"Paul", "George"},
+		Particle:             "van der",
+		Last:                 "Berg-Smith",
+		GenerationalSuffix:   "Jr.",
+		ProfessionalSuffixes: []string{"PhD", "MD"},
+	}
+	assert.Equal(t, "van der Berg-Smith", name.Surname())
+	assert.Equal(t, "John Paul George van der Berg-Smith Jr.", name.String())
+	assert.Equal(t, "Dr John Paul George van der Berg-Smith Jr., PhD, MD", name.Formal())
+	assert.Equal(t, "J.P.G.B.", name.Initials())
+	assert.Equal(t, "van der Berg-Smith, John Paul George, Jr.", name.LastFirst())
+
+	plain := PersonName{First: "Jane", Last: "Doe"}
+	assert.Equal(t, "Jane Doe", plain.String())
+	assert.Equal(t, "Jane Doe", plain.Formal())
+	assert.Equal(t, "J.D.", plain.Initials())
+	assert.Equal(t, "Doe, Jane", plain.LastFirst())
+}
@@ -0,0 +1,574 @@
+package randomdata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rules obtained from:
+// * https://www.ssa.gov/employer/randomization.html (US)
+// * https://www.gov.uk/hmrc-internal-manuals/national-insurance-manual/nim39110 (GB)
+// * https://fr.wikipedia.org/wiki/Numéro_de_sécurité_sociale_en_France (FR)
+// * https://sv.wikipedia.org/wiki/Personnummer_i_Sverige (SE)
+// * https://nl.wikipedia.org/wiki/Burgerservicenummer (NL)
+// * https://de.wikipedia.org/wiki/Steuerliche_Identifikationsnummer (DE)
+// * https://www.interior.gob.es/opencms/es/servicios-al-ciudadano/tramites-y-gestiones/dni/calculo-del-digito-de-control-del-nif-nie/ (ES)
+// * https://it.wikipedia.org/wiki/Codice_fiscale (IT)
+// * https://uidai.gov.in (IN)
+// * https://pt.wikipedia.org/wiki/Cadastro_de_pessoas_físicas (BR)
+
+type nationalIDFormat struct {
+	generate func(r *Rand) string
+	validate func(id string) bool
+}
+
+var nationalIDFormats = map[string]nationalIDFormat{
+	"US": {(*Rand).ssn, validSSN},
+	"GB": {(*Rand).nino, validNINO},
+	"FR": {(*Rand).nir, validNIR},
+	"SE": {(*Rand).personnummer, validPersonnummer},
+	"NL": {(*Rand).bsn, validBSN},
+	"DE": {(*Rand).steuerID, validSteuerID},
+	"ES": {(*Rand).dniOrNIE, validDNIOrNIE},
+	"IT": {(*Rand).codiceFiscale, validCodiceFiscale},
+	"IN": {(*Rand).aadhaar, validAadhaar},
+	"BR": {(*Rand).cpf, validCPF},
+}
+
+// NationalID returns a random national identification number for the supplied 2-letter country code.
+// The number follows the structure of the real identifier and carries a valid check digit where the
+// identifier has one. If the country is not supported it will return an empty string.
+//
+// Supported identifiers are the US SSN, UK NINO, French NIR, Swedish personnummer, Dutch BSN,
+// German Steuer-ID, Spanish DNI/NIE, Italian codice fiscale, Indian Aadhaar and Brazilian CPF.
+func (r *Rand) NationalID(countrycode string) string {
+	format, ok := nationalIDFormats[strings.ToUpper(countrycode)]
+	if !ok {
+		return ""
+	}
+	return format.generate(r)
+}
+
+// ValidateNationalID reports whether id is a valid national identification number for the supplied
+// 2-letter country code. Spaces and the usual separators are accepted.
+// If the country is not supported it will return false.
+func ValidateNationalID(countrycode, id string) bool {
+	format, ok := nationalIDFormats[strings.ToUpper(countrycode)]
+	if !ok {
+		return false
+	}
+	return format.validate(id)
+}
+
+// birthDate returns a random date of birth between 1940 and 2005.
+func (r *Rand) birthDate() time.Time {
+	start := time.Date(1940, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return start.AddDate(0, 0, r.Intn(int(end.Sub(start).Hours()/24)))
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isLetters(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func stripSeparators(s string, separators string) string {
+	return strings.Map(func(c rune) rune {
+		if strings.ContainsRune(separators, c) {
+			return -1
+		}
+		return c
+	}, s)
+}
+
+// US Social Security Number: AAA-GG-SSSS where the area is 001-899 except 666,
+// the group is 01-99 and the serial is 0001-9999.
+func (r *Rand) ssn() string {
+	area := r.Number(1, 899)
+	if area >= 666 {
+		area++
+	}
+	return fmt.Sprintf("%03d-%02d-%04d", area, r.Number(1, 100), r.Number(1, 10000))
+}
+
+func validSSN(id string) bool {
+	id = stripSeparators(id, " -")
+	if len(id) != 9 || !isDigits(id) {
+		return false
+	}
+	area, _ := strconv.Atoi(id[:3])
+	group, _ := strconv.Atoi(id[3:5])
+	serial, _ := strconv.Atoi(id[5:])
+	return area > 0 && area < 900 && area != 666 && group > 0 && serial > 0
+}
+
+// UK National Insurance number: two prefix letters, six digits and a suffix letter A-D.
+const (
+	ninoFirstLetters  = "ABCEGHJKLMNOPRSTWXYZ"
+	ninoSecondLetters = "ABCEGHJKLMNPRSTWXYZ"
+	ninoSuffixLetters = "ABCD"
+)
+
+var ninoForbiddenPrefixes = []string{"BG", "GB", "KN", "NK", "NT", "TN", "ZZ"}
+
+func (r *Rand) nino() string {
+	for {
+		prefix := string(ninoFirstLetters[r.Intn(len(ninoFirstLetters))]) +
+			string(ninoSecondLetters[r.Intn(len(ninoSecondLetters))])
+		if containsString(ninoForbiddenPrefixes, prefix) {
+			continue
+		}
+		return prefix + r.Digits(6) + string(ninoSuffixLetters[r.Intn(len(ninoSuffixLetters))])
+	}
+}
+
+func validNINO(id string) bool {
+	id = strings.ToUpper(stripSeparators(id, " "))
+	if len(id) != 9 {
+		return false
+	}
+	return strings.IndexByte(ninoFirstLetters, id[0]) >= 0 &&
+		strings.IndexByte(ninoSecondLetters, id[1]) >= 0 &&
+		!containsString(ninoForbiddenPrefixes, id[:2]) &&
+		isDigits(id[2:8]) &&
+		strings.IndexByte(ninoSuffixLetters, id[8]) >= 0
+}
+
+// French NIR (numéro de sécurité sociale): sex, year, month, department, commune, order number
+// and a 2-digit key equal to 97 minus the number modulo 97.
+func (r *Rand) nir() string {
+	dob := r.birthDate()
+	var department string
+	switch n := r.Intn(96); {
+	case n == 20:
+		department = r.StringFrom([]string{"2A", "2B"})
+	case n == 0:
+		department = "99"
+	default:
+		department = fmt.Sprintf("%02d", n)
+	}
+	number := fmt.Sprintf("%d%02d%02d%s%03d%03d",
+		r.Number(1, 3),
+		dob.Year()%100,
+		int(dob.Month()),
+		department,
+		r.Number(1, 991),
+		r.Number(1, 1000),
+	)
+	return fmt.Sprintf("%s%02d", number, nirKey(number))
+}
+
+func nirKey(number string) int {
+	// Corsican departments are replaced by their historical numeric value.
+	number = strings.Replace(number, "2A", "19", 1)
+	number = strings.Replace(number, "2B", "18", 1)
+	n, _ := strconv.ParseInt(number, 10, 64)
+	return int(97 - n%97)
+}
+
+func validNIR(id string) bool {
+	id = strings.ToUpper(stripSeparators(id, " "))
+	if len(id) != 15 || !strings.ContainsRune("123478", rune(id[0])) {
+		return false
+	}
+	if !isDigits(id[:5]) || !isDigits(id[7:]) || (!isDigits(id[5:7]) && id[5:7] != "2A" && id[5:7] != "2B") {
+		return false
+	}
+	month, _ := strconv.Atoi(id[3:5])
+	if month < 1 || (month > 12 && month < 20) {
+		return false
+	}
+	key, _ := strconv.Atoi(id[13:])
+	return key == nirKey(id[:13])
+}
+
+// Swedish personnummer: YYMMDD-NNNC where C is a Luhn check digit.
+func (r *Rand) personnummer() string {
+	dob := r.birthDate()
+	number := dob.Format("060102") + r.Digits(3)
+	return fmt.Sprintf("%s-%s%d", number[:6], number[6:], luhnCheckDigit(number))
+}
+
+func validPersonnummer(id string) bool {
+	id = stripSeparators(id, "-+ ")
+	if len(id) == 12 {
+		id = id[2:]
+	}
+	if len(id) != 10 || !isDigits(id) {
+		return false
+	}
+	day, _ := strconv.Atoi(id[4:6])
+	if day > 60 {
+		// Samordningsnummer (coordination numbers) add 60 to the day.
+		day -= 60
+	}
+	month, _ := strconv.Atoi(id[2:4])
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return false
+	}
+	return int(id[9]-'0') == luhnCheckDigit(id[:9])
+}
+
+// luhnCheckDigit computes the Luhn check digit of a string of digits.
+func luhnCheckDigit(digits string) int {
+	sum := 0
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return (10 - sum%10) % 10
+}
+
+// Dutch burgerservicenummer: 9 digits satisfying the "elfproef" (11-check).
+func (r *Rand) bsn() string {
+	for {
+		number := strconv.Itoa(r.Number(1, 10)) + r.Digits(7)
+		sum := 0
+		for i := 0; i < 8; i++ {
+			sum += (9 - i) * int(number[i]-'0')
+		}
+		if check := sum % 11; check < 10 {
+			return number + strconv.Itoa(check)
+		}
+	}
+}
+
+func validBSN(id string) bool {
+	id = stripSeparators(id, " .")
+	if len(id) == 8 {
+		id = "0" + id
+	}
+	if len(id) != 9 || !isDigits(id) || id == "000000000" {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 8; i++ {
+		sum += (9 - i) * int(id[i]-'0')
+	}
+	sum -= int(id[8] - '0')
+	return sum%11 == 0
+}
+
+// German Steuerliche Identifikationsnummer: 11 digits, no leading zero, one digit of the first ten
+// repeated exactly once, and an ISO 7064 MOD 11,10 check digit.
+func (r *Rand) steuerID() string {
+	for {
+		digits := []byte("0123456789")
+		for i := len(digits) - 1; i > 0; i-- {
+			j := r.Intn(i + 1)
+			digits[i], digits[j] = digits[j], digits[i]
+		}
+		// Drop one digit and repeat another one at a random position.
+		digits = digits[:9]
+		repeated := digits[r.Intn(9)]
+		pos := r.Intn(10)
+		digits = append(digits[:pos], append([]byte{repeated}, digits[pos:]...)...)
+		if digits[0] == '0' {
+			continue
+		}
+		number := string(digits)
+		return number + strconv.Itoa(iso7064Mod1110(number))
+	}
+}
+
+func iso7064Mod1110(digits string) int {
+	product := 10
+	for i := 0; i < len(digits); i++ {
+		sum := (int(digits[i]-'0') + product) % 10
+		if sum == 0 {
+			sum = 10
+		}
+		product = (sum * 2) % 11
+	}
+	check := 11 - product
+	if check == 10 {
+		check = 0
+	}
+	return check
+}
+
+func validSteuerID(id string) bool {
+	id = stripSeparators(id, " /")
+	if len(id) != 11 || !isDigits(id) || id[0] == '0' {
+		return false
+	}
+	counts := map[byte]int{}
+	for i := 0; i < 10; i++ {
+		counts[id[i]]++
+	}
+	repeated := 0
+	for digit, count := range counts {
+		switch {
+		case count == 2:
+			repeated++
+		case count == 3:
+			// Since 2016 a digit may occur three times, but never three times in a row.
+			if strings.Contains(id[:10], strings.Repeat(string(digit), 3)) {
+				return false
+			}
+			repeated++
+		case count > 3:
+			return false
+		}
+	}
+	if repeated != 1 {
+		return false
+	}
+	return int(id[10]-'0') == iso7064Mod1110(id[:10])
+}
+
+// Spanish DNI (8 digits and a check letter) and NIE (X, Y or Z, 7 digits and a check letter).
+const dniLetters = "TRWAGMYFPDXBNJZSQVHLCKE"
+
+func (r *Rand) dniOrNIE() string {
+	if r.Intn(5) == 0 {
+		prefix := r.Intn(3)
+		number := r.Digits(7)
+		n, _ := strconv.Atoi(strconv.Itoa(prefix) + number)
+		return string("XYZ"[prefix]) + number + string(dniLetters[n%23])
+	}
+	number := r.Digits(8)
+	n, _ := strconv.Atoi(number)
+	return number + string(dniLetters[n%23])
+}
+
+func validDNIOrNIE(id string) bool {
+	id = strings.ToUpper(stripSeparators(id, " -"))
+	if len(id) != 9 {
+		return false
+	}
+	if prefix := strings.IndexByte("XYZ", id[0]); prefix >= 0 {
+		id = strconv.Itoa(prefix) + id[1:]
+	}
+	if !isDigits(id[:8]) {
+		return false
+	}
+	n, _ := strconv.Atoi(id[:8])
+	return id[8] == dniLetters[n%23]
+}
+
+// Italian codice fiscale: surname, name, date of birth and sex, place of birth and a check letter.
+const (
+	cfMonthLetters = "ABCDEHLMPRST"
+	cfOmocodia     = "LMNPQRSTUV"
+)
+
+// cfOddValues holds the values of the characters 0-9 followed by A-Z at odd (1-based) positions.
+var cfOddValues = []int{
+	1, 0, 5, 7, 9, 13, 15, 17, 19, 21,
+	1, 0, 5, 7, 9, 13, 15, 17, 19, 21, 2, 4, 18, 20, 11, 3, 6, 8, 12, 14, 16, 10, 22, 25, 24, 23,
+}
+
+// cfPlaces contains a few Belfiore codes: large Italian cities followed by foreign countries.
+var cfPlaces = []string{
+	"H501", "F205", "F839", "L219", "G273", "D969", "A944", "D612", "A662", "C351", "L736", "L781",
+	"Z100", "Z110", "Z112", "Z114", "Z404",
+}
+
+func (r *Rand) codiceFiscale() string {
+	gender := Gender(r.Intn(2))
+	dob := r.birthDate()
+	day := dob.Day()
+	if gender == Female {
+		day += 40
+	}
+	code := cfNamePart(r.LastName(), false) +
+		cfNamePart(r.FirstName(gender), true) +
+		fmt.Sprintf("%02d%c%02d", dob.Year()%100, cfMonthLetters[dob.Month()-1], day) +
+		r.StringFrom(cfPlaces)
+	return code + string(cfCheckLetter(code))
+}
+
+// cfNamePart encodes a surname or a first name using its consonants first, then its vowels, padded with X.
+// First names with more than three consonants use the first, third and fourth one.
+func cfNamePart(name string, firstName bool) string {
+	var consonants, vowels []byte
+	for _, c := range []byte(strings.ToUpper(name)) {
+		switch {
+		case strings.IndexByte("AEIOU", c) >= 0:
+			vowels = append(vowels, c)
+		case c >= 'A' && c <= 'Z':
+			consonants = append(consonants, c)
+		}
+	}
+	if firstName && len(consonants) > 3 {
+		consonants = []byte{consonants[0], consonants[2], consonants[3]}
+	}
+	part := string(consonants) + string(vowels) + "XXX"
+	return part[:3]
+}
+
+func cfCheckLetter(code string) byte {
+	sum := 0
+	for i := 0; i < 15; i++ {
+		idx := int(code[i] - '0')
+		if code[i] >= 'A' {
+			idx = int(code[i]-'A') + 10
+		}
+		switch {
+		case i%2 == 0:
+			sum += cfOddValues[idx]
+		case idx >= 10:
+			sum += idx - 10
+		default:
+			sum += idx
+		}
+	}
+	return byte('A' + sum%26)
+}
+
+func validCodiceFiscale(id string) bool {
+	id = strings.ToUpper(stripSeparators(id, " "))
+	if len(id) != 16 {
+		return false
+	}
+	for i := 0; i < 16; i++ {
+		c := id[i]
+		switch i {
+		case 6, 7, 9, 10, 12, 13, 14:
+			// Digits may be replaced by letters to resolve collisions (omocodia).
+			if !(c >= '0' && c <= '9') && strings.IndexByte(cfOmocodia, c) < 0 {
+				return false
+			}
+		default:
+			if c < 'A' || c > 'Z' {
+				return false
+			}
+		}
+	}
+	if strings.IndexByte(cfMonthLetters, id[8]) < 0 {
+		return false
+	}
+	day := cfDigit(id[9])*10 + cfDigit(id[10])
+	if day < 1 || (day > 31 && day < 41) || day > 71 {
+		return false
+	}
+	return id[15] == cfCheckLetter(id)
+}
+
+func cfDigit(c byte) int {
+	if i := strings.IndexByte(cfOmocodia, c); i >= 0 {
+		return i
+	}
+	return int(c - '0')
+}
+
+// Indian Aadhaar number: 12 digits, not starting with 0 or 1, with a Verhoeff check digit.
+var (
+	verhoeffD = [10][10]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
+		{2, 3, 4, 0, 1, 7, 8, 9, 5, 6},
+		{3, 4, 0, 1, 2, 8, 9, 5, 6, 7},
+		{4, 0, 1, 2, 3, 9, 5, 6, 7, 8},
+		{5, 9, 8, 7, 6, 0, 4, 3, 2, 1},
+		{6, 5, 9, 8, 7, 1, 0, 4, 3, 2},
+		{7, 6, 5, 9, 8, 2, 1, 0, 4, 3},
+		{8, 7, 6, 5, 9, 3, 2, 1, 0, 4},
+		{9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+	}
+	verhoeffP = [8][10]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
+		{5, 8, 0, 3, 7, 9, 6, 1, 4, 2},
+		{8, 9, 1, 6, 0, 4, 3, 5, 2, 7},
+		{9, 4, 5, 3, 1, 2, 6, 8, 7, 0},
+		{4, 2, 8, 6, 5, 7, 3, 9, 0, 1},
+		{2, 7, 9, 3, 8, 0, 6, 4, 1, 5},
+		{7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
+	}
+	verhoeffInv = [10]int{0, 4, 3, 2, 1, 5, 6, 7, 8, 9}
+)
+
+// verhoeffCheckDigit computes the Verhoeff check digit of a string of digits.
+func verhoeffCheckDigit(digits string) int {
+	c := 0
+	for i := 0; i < len(digits); i++ {
+		c = verhoeffD[c][verhoeffP[(i+1)%8][digits[len(digits)-1-i]-'0']]
+	}
+	return verhoeffInv[c]
+}
+
+func (r *Rand) aadhaar() string {
+	number := strconv.Itoa(r.Number(2, 10)) + r.Digits(10)
+	number += strconv.Itoa(verhoeffCheckDigit(number))
+	return number[:4] + " " + number[4:8] + " " + number[8:]
+}
+
+func validAadhaar(id string) bool {
+	id = stripSeparators(id, " -")
+	if len(id) != 12 || !isDigits(id) || id[0] < '2' {
+		return false
+	}
+	return int(id[11]-'0') == verhoeffCheckDigit(id[:11])
+}
+
+// Brazilian Cadastro de Pessoas Físicas: 9 digits followed by two mod 11 check digits.
+func (r *Rand) cpf() string {
+	for {
+		number := r.Digits(9)
+		if strings.Count(number, number[:1]) == len(number) {
+			continue
+		}
+		number += strconv.Itoa(cpfCheckDigit(number))
+		number += strconv.Itoa(cpfCheckDigit(number))
+		return fmt.Sprintf("%s.%s.%s-%s", number[:3], number[3:6], number[6:9], number[9:])
+	}
+}
+
+func cpfCheckDigit(digits string) int {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		sum += int(digits[i]-'0') * (len(digits) + 1 - i)
+	}
+	if rest := sum % 11; rest >= 2 {
+		return 11 - rest
+	}
+	return 0
+}
+
+func validCPF(id string) bool {
+	id = stripSeparators(id, " .-")
+	if len(id) != 11 || !isDigits(id) || strings.Count(id, id[:1]) == len(id) {
+		return false
+	}
+	return int(id[9]-'0') == cpfCheckDigit(id[:9]) && int(id[10]-'0') == cpfCheckDigit(id[:10])
+}
+
+func containsString(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package randomdata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var nationalIDTests = []struct {
+	Country string
+	Valid   []string
+	Invalid []string
+}{
+	{"US", []string{"078-05-1120", "123456789"}, []string{"000-12-3456", "666-12-3456", "900-12-3456", "123-00-4567", "123-45-0000", "123-45-67890"}},
+	{"GB", []string{"AB123456C", "ab 12 34 56 d"}, []string{"QQ123456C", "GB123456A", "AO123456A", "AB123456E", "AB12345C"}},
+	{"FR", []string{"255081416802538", "2 55 08 14 168 025 38"}, []string{"255081416802539", "555081416802538", "2551314168025"}},
+	{"SE", []string{"811228-9874", "19811228-9874", "8112289874"}, []string{"811228-9875", "811328-9874", "811200-9874"}},
+	{"NL", []string{"111222333", "123456782"}, []string{"111222334", "000000000", "12345678A"}},
+	{"DE", []string{"86095742719"}, []string{"86095742718", "06095742719", "12345678903", "11111111116"}},
+	{"ES", []string{"12345678Z", "X1234567L", "12345678-z"}, []string{"12345678A", "W1234567L", "1234567Z"}},
+	{"IT", []string{"RSSMRA85T10A562S", "rssmra85t10a562s"}, []string{"RSSMRA85T10A562T", "RSSMRA85Z10A562S", "RSSMRA85T35A562S"}},
+	{"IN", []string{"234123412346", "2341 2341 2346"}, []string{"234123412347", "134123412346", "23412341234"}},
+	{"BR", []string{"529.982.247-25", "52998224725"}, []string{"529.982.247-26", "111.111.111-11", "5299822472"}},
+}
+
+func TestNationalID(t *testing.T) {
+	r := FromSeed(1234)
+	for _, nt := range nationalIDTests {
+		for i := 0; i < 1000; i++ {
+			id := r.NationalID(nt.Country)
+			assert.True(t, ValidateNationalID(nt.Country, id), "invalid national id for country %q: %s", nt.Country, id)
+		}
+	}
+	assert.Empty(t, r.NationalID("bogus"), "did not return empty national id for unknown country")
+}
+
+func TestValidateNationalID(t *testing.T) {
+	for _, nt := range nationalIDTests {
+		for _, id := range nt.Valid {
+			assert.True(t, ValidateNationalID(nt.Country, id), "expected %s to be valid for country %q", id, nt.Country)
+		}
+		for _, id := range nt.Invalid {
+			assert.False(t, ValidateNationalID(nt.Country, id), "expected %s to be invalid for country %q", id, nt.Country)
+		}
+	}
+	assert.False(t, ValidateNationalID("bogus", "123456789"), "did not reject unknown country")
+}
@@ -0,0 +1,189 @@
+package randomdata
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Patterns describe the shape of codes such as postal codes or phone numbers, and are used both to generate
+// and to validate them. A pattern is made of:
+// * '#' a digit
+// * '@' a letter from A to Z
+// * [...] a character from a set, in which ranges such as A-H are allowed, e.g. [A-HJ-NP-Z]
+// * {low-high} a number between low and high included, padded with zeros to the width of low
+// * any other character, written as is
+
+// patternToken is one character, picked from set, or a number between low and high.
+type patternToken struct {
+	set       string
+	low, high int
+	width     int
+}
+
+func parsePattern(pattern string) ([]patternToken, error) {
+	var tokens []patternToken
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '#':
+			tokens = append(tokens, patternToken{set: "0123456789"})
+		case '@':
+			tokens = append(tokens, patternToken{set: "ABCDEFGHIJKLMNOPQRSTUVWXYZ"})
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 2 {
+				return nil, fmt.Errorf("randomdata: invalid set in pattern %q", pattern)
+			}
+			var set strings.Builder
+			class := pattern[i+1 : i+end]
+			for j := 0; j < len(class); j++ {
+				if j+2 < len(class) && class[j+1] == '-' {
+					if class[j] > class[j+2] {
+						return nil, fmt.Errorf("randomdata: invalid set in pattern %q", pattern)
+					}
+					// An int counter, as a byte one would overflow on ranges ending at 0xFF.
+					for k := int(class[j]); k <= int(class[j+2]); k++ {
+						set.WriteByte(byte(k))
+					}
+					j += 2
+				} else {
+					set.WriteByte(class[j])
+				}
+			}
+			tokens = append(tokens, patternToken{set: set.String()})
+			i += end
+		case '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			bounds := []string{}
+			if end > 0 {
+				bounds = strings.SplitN(pattern[i+1:i+end], "-", 2)
+			}
+			if len(bounds) != 2 {
+				return nil, fmt.Errorf("randomdata: invalid range in pattern %q", pattern)
+			}
+			low, err1 := strconv.Atoi(bounds[0])
+			high, err2 := strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil || low > high || len(bounds[1]) != len(bounds[0]) {
+				return nil, fmt.Errorf("randomdata: invalid range in pattern %q", pattern)
+			}
+			tokens = append(tokens, patternToken{low: low, high: high, width: len(bounds[0])})
+			i += end
+		default:
+			tokens = append(tokens, patternToken{set: string(c)})
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("randomdata: empty pattern")
+	}
+	return tokens, nil
+}
+
+func (r *Rand) fromPattern(tokens []patternToken) string {
+	var code strings.Builder
+	for _, token := range tokens {
+		if token.width > 0 {
+			fmt.Fprintf(&code, "%0*d", token.width, r.Number(token.low, token.high+1))
+		} else {
+			code.WriteByte(token.set[r.Intn(len(token.set))])
+		}
+	}
+	return code.String()
+}
+
+func matchAnyPattern(patterns [][]patternToken, code string) bool {
+	for _, tokens := range patterns {
+		if matchPattern(tokens, code) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPattern(tokens []patternToken, code string) bool {
+	for _, token := range tokens {
+		if token.width > 0 {
+			if len(code) < token.width || !isDigits(code[:token.width]) {
+				return false
+			}
+			n, _ := strconv.Atoi(code[:token.width])
+			if n < token.low || n > token.high {
+				return false
+			}
+			code = code[token.width:]
+			continue
+		}
+		if code == "" || strings.IndexByte(token.set, code[0]) < 0 {
+			return false
+		}
+		code = code[1:]
+	}
+	return code == ""
+}
+
+// patternWidth returns the number of characters of the codes of a pattern.
+func patternWidth(tokens []patternToken) int {
+	width := 0
+	for _, token := range tokens {
+		if token.width > 0 {
+			width += token.width
+		} else {
+			width++
+		}
+	}
+	return width
+}
+
+// patternCovers tells whether every code of the allowed pattern matches the excluded one.
+// It compares the patterns character by character, so it misses exclusions made of several patterns
+// or of ranges split differently, but catches the ones making a pattern impossible to generate.
+func patternCovers(excluded, allowed []patternToken) bool {
+	if samePattern(excluded, allowed) {
+		return true
+	}
+	if patternWidth(excluded) != patternWidth(allowed) {
+		return false
+	}
+	excludedSets, allowedSets := patternSets(excluded, false), patternSets(allowed, true)
+	for i, set := range allowedSets {
+		for j := 0; j < len(set); j++ {
+			if strings.IndexByte(excludedSets[i], set[j]) < 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// patternSets returns the characters allowed at each position of the codes of a pattern.
+// A range of numbers is approximated by digits at each of its positions: always if widen is set, otherwise
+// only when it allows every number of its width, the positions of other ranges allowing no character.
+func patternSets(tokens []patternToken, widen bool) []string {
+	var sets []string
+	for _, token := range tokens {
+		if token.width == 0 {
+			sets = append(sets, token.set)
+			continue
+		}
+		digits := ""
+		if widen || token.low == 0 && token.high == int(math.Pow10(token.width))-1 {
+			digits = "0123456789"
+		}
+		for j := 0; j < token.width; j++ {
+			sets = append(sets, digits)
+		}
+	}
+	return sets
+}
+
+func samePattern(a, b []patternToken) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
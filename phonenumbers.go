@@ -0,0 +1,393 @@
+package randomdata
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Numbering plans obtained from:
+// * https://www.itu.int/oth/T0202 (national numbering plans published by the ITU)
+// * https://github.com/google/libphonenumber/blob/master/resources/PhoneNumberMetadata.xml
+
+// PhoneKind is the kind of line a phone number reaches.
+type PhoneKind int
+
+const (
+	Landline PhoneKind = iota
+	Mobile
+	TollFree
+	Premium
+)
+
+// String returns the name of the kind of phone number.
+func (k PhoneKind) String() string {
+	switch k {
+	case Landline:
+		return "landline"
+	case Mobile:
+		return "mobile"
+	case TollFree:
+		return "toll-free"
+	case Premium:
+		return "premium"
+	}
+	return "unknown"
+}
+
+var phoneKinds = []PhoneKind{Landline, Mobile, TollFree, Premium}
+
+// numberingPlan describes the national significant numbers of a country, i.e. without the calling code
+// nor the trunk prefix. Patterns follow the syntax described in pattern.go, their spaces grouping the digits
+// as written in the country.
+type numberingPlan struct {
+	trunk         string // prefix dialled before national numbers within the country
+	trunkOptional bool   // whether national numbers are commonly written without the trunk prefix
+	trunkless     string // leading digits of the numbers dialled without the trunk prefix
+	ranges        map[PhoneKind][]string
+}
+
+// nanpNumbers returns the patterns of the numbers of the supplied NANP area codes.
+func nanpNumbers(areaCodes ...string) []string {
+	patterns := make([]string, len(areaCodes))
+	for i, areaCode := range areaCodes {
+		patterns[i] = areaCode + " [2-9]## ####"
+	}
+	return patterns
+}
+
+var (
+	nanpTollFree = nanpNumbers("800", "833", "844", "855", "866", "877", "888")
+	nanpPremium  = nanpNumbers("900")
+)
+
+var numberingPlans = map[string]numberingPlan{
+	"US": {trunk: "1", trunkOptional: true, ranges: map[PhoneKind][]string{
+		// Landlines and mobiles share the same area codes in the NANP.
+		Landline: nanpNumbers("201", "202", "203", "205", "206", "212", "213", "214", "215", "216", "303", "305", "310",
+			"312", "313", "404", "408", "412", "415", "503", "512", "602", "615", "617", "646", "702", "704", "713",
+			"718", "801", "818", "917", "919"),
+		Mobile:   nanpNumbers("206", "212", "310", "312", "347", "415", "469", "617", "646", "702", "786", "917", "929"),
+		TollFree: nanpTollFree,
+		Premium:  nanpPremium,
+	}},
+	"CA": {trunk: "1", trunkOptional: true, ranges: map[PhoneKind][]string{
+		Landline: nanpNumbers("204", "250", "306", "403", "416", "418", "438", "450", "506", "514", "519", "587", "604",
+			"613", "647", "709", "778", "780", "819", "902", "905"),
+		Mobile:   nanpNumbers("236", "343", "365", "416", "437", "438", "514", "587", "604", "647", "778", "825"),
+		TollFree: nanpTollFree,
+		Premium:  nanpPremium,
+	}},
+	"GB": {trunk: "0", ranges: map[PhoneKind][]string{
+		Landline: {"20 [378]### ####", "121 [2-9]## ####", "131 [2-9]## ####", "161 [2-9]## ####", "113 [2-9]## ####",
+			"117 [2-9]## ####", "29 [2-9]### ####", "28 9### ####", "1223 [2-9]#####", "1865 [2-9]#####"},
+		Mobile:   {"7[1-57-9]## ######"},
+		TollFree: {"800 ### ####", "808 ### ####"},
+		Premium:  {"90# ### ####", "91# ### ####", "98# ### ####"},
+	}},
+	"DE": {trunk: "0", ranges: map[PhoneKind][]string{
+		Landline: {"30 [1-9]#######", "40 [1-9]#######", "69 [1-9]#######", "89 [1-9]#######", "221 [1-9]######",
+			"211 [1-9]######", "711 [1-9]######"},
+		Mobile:   {"15[12579] ########", "16[023] #######", "17[0-5] #######", "17[6-9] ########"},
+		TollFree: {"800 #######"},
+		Premium:  {"900 [135]######"},
+	}},
+	"FR": {trunk: "0", ranges: map[PhoneKind][]string{
+		Landline: {"[1-5] ## ## ## ##", "9 [1-7]# ## ## ##"},
+		Mobile:   {"6 ## ## ## ##", "7 [3-9]# ## ## ##"},
+		TollFree: {"80[05] ## ## ##"},
+		Premium:  {"89[1-9] ## ## ##"},
+	}},
+	"NL": {trunk: "0", ranges: map[PhoneKind][]string{
+		Landline: {"10 ### ####", "20 ### ####", "30 ### ####", "40 ### ####", "70 ### ####", "50 ### ####"},
+		Mobile:   {"6 [1-5]#######"},
+		TollFree: {"800 ####", "800 #######"},
+		Premium:  {"90[069] ####", "90[069] #######"},
+	}},
+	"ES": {ranges: map[PhoneKind][]string{
+		Landline: {"9[1-8]# ## ## ##", "8[1-8]# ## ## ##"},
+		Mobile:   {"6## ## ## ##", "7[1-4]# ## ## ##"},
+		TollFree: {"900 ## ## ##", "800 ## ## ##"},
+		Premium:  {"80[3-7] ## ## ##", "90[3-7] ## ## ##"},
+	}},
+	"IT": {ranges: map[PhoneKind][]string{
+		Landline: {"06 #### ####", "02 #### ####", "011 ### ####", "055 ### ####", "081 ### ####", "051 ### ####"},
+		Mobile:   {"3[1-9]# ### ####"},
+		TollFree: {"800 ######", "803 ######"},
+		Premium:  {"89[2-9] ######"},
+	}},
+	"SE": {trunk: "0", ranges: map[PhoneKind][]string{
+		Landline: {"8 ### ### ##", "31 ### ## ##", "40 ### ## ##", "18 ## ## ##", "90 [1-9]# ## ##"},
+		Mobile:   {"7[02369] ### ## ##"},
+		TollFree: {"20 ### ###"},
+		Premium:  {"900 ### ##", "939 ### ##", "944 ### ##"},
+	}},
+	"BR": {trunk: "0", trunkOptional: true, ranges: map[PhoneKind][]string{
+		Landline: {"11 [2-5]### ####", "21 [2-5]### ####", "31 [2-5]### ####", "41 [2-5]### ####", "51 [2-5]### ####",
+			"61 [2-5]### ####", "71 [2-5]### ####", "81 [2-5]### ####", "85 [2-5]### ####"},
+		Mobile: {"11 9#### ####", "21 9#### ####", "31 9#### ####", "41 9#### ####", "51 9#### ####", "61 9#### ####",
+			"71 9#### ####", "81 9#### ####", "85 9#### ####"},
+		TollFree: {"800 ### ####"},
+		Premium:  {"900 ### ####"},
+	}},
+	"AU": {trunk: "0", trunkless: "1", ranges: map[PhoneKind][]string{
+		Landline: {"[2378] [3-9]### ####"},
+		Mobile:   {"4## ### ###"},
+		TollFree: {"1800 ### ###"},
+		Premium:  {"190[0-2] ### ###"},
+	}},
+	"IN": {trunk: "0", trunkless: "18", ranges: map[PhoneKind][]string{
+		Landline: {"11 [2-6]### ####", "22 [2-6]### ####", "33 [2-6]### ####", "44 [2-6]### ####", "80 [2-6]### ####"},
+		Mobile:   {"[6-9]#### #####"},
+		TollFree: {"1800 ### ####"},
+	}},
+	"JP": {trunk: "0", ranges: map[PhoneKind][]string{
+		Landline: {"3 #### ####", "6 #### ####", "52 ### ####", "45 ### ####", "92 ### ####"},
+		Mobile:   {"[789]0 #### ####"},
+		TollFree: {"120 ### ###"},
+		Premium:  {"990 ### ###"},
+	}},
+}
+
+// PhoneNumber is a phone number split into its parts.
+type PhoneNumber struct {
+	Country     string    `json:"country"`     // 2-letter country code
+	CallingCode string    `json:"callingCode"` // without the leading '+', e.g. "44"
+	Number      string    `json:"number"`      // national significant number, digits only
+	Kind        PhoneKind `json:"kind"`
+	Extension   string    `json:"extension,omitempty"`
+}
+
+// PhoneNumberFor returns a random phone number of the supplied kind, following the numbering plan of the supplied
+// 2-letter country code.
+// If the country or the kind is not supported it will return an empty PhoneNumber.
+func (r *Rand) PhoneNumberFor(countrycode string, kind PhoneKind) PhoneNumber {
+	countrycode = strings.ToUpper(countrycode)
+	patterns := numberingPlans[countrycode].ranges[kind]
+	if len(patterns) == 0 {
+		return PhoneNumber{}
+	}
+	tokens, _ := parsePattern(strings.Replace(r.StringFrom(patterns), " ", "", -1))
+	return PhoneNumber{
+		Country:     countrycode,
+		CallingCode: strings.TrimPrefix(jsonData.CountryInfo[countrycode].CallingCode, "+"),
+		Number:      r.fromPattern(tokens),
+		Kind:        kind,
+	}
+}
+
+// SupportedPhoneCountries returns the sorted 2-letter codes of the countries supported by PhoneNumberFor.
+func SupportedPhoneCountries() []string {
+	countries := make([]string, 0, len(numberingPlans))
+	for country := range numberingPlans {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
+}
+
+// WithExtension returns a copy of the phone number with the supplied extension.
+func (p PhoneNumber) WithExtension(extension string) PhoneNumber {
+	p.Extension = extension
+	return p
+}
+
+// E164 returns the number in E.164 format, e.g. "+442079460321". E.164 has no room for the extension.
+func (p PhoneNumber) E164() string {
+	if p.Number == "" {
+		return ""
+	}
+	return "+" + p.CallingCode + p.Number
+}
+
+// International returns the number as dialled from abroad, e.g. "+44 20 7946 0321".
+func (p PhoneNumber) International() string {
+	if p.Number == "" {
+		return ""
+	}
+	return "+" + p.CallingCode + " " + strings.Join(p.groups(), " ") + p.extension(" ext. ")
+}
+
+// National returns the number as written within its country, e.g. "020 7946 0321" or "(212) 555-0123".
+func (p PhoneNumber) National() string {
+	if p.Number == "" {
+		return ""
+	}
+	plan := numberingPlans[p.Country]
+	groups := p.groups()
+	trunk := plan.trunk
+	if plan.trunkOptional || (plan.trunkless != "" && strings.HasPrefix(p.Number, plan.trunkless)) {
+		trunk = ""
+	}
+	var national string
+	switch {
+	case len(groups) == 3 && p.CallingCode == "1":
+		national = "(" + groups[0] + ") " + groups[1] + "-" + groups[2]
+	case p.Country == "BR" && (p.Kind == Landline || p.Kind == Mobile):
+		national = "(" + groups[0] + ") " + strings.Join(groups[1:], "-")
+	case p.Country == "BR":
+		// Non-geographic numbers always show the trunk prefix, e.g. "0800 123 4567".
+		national = plan.trunk + strings.Join(groups, " ")
+	case p.Country == "JP":
+		national = trunk + strings.Join(groups, "-")
+	case p.Country == "SE" && len(groups) > 1:
+		national = trunk + groups[0] + "-" + strings.Join(groups[1:], " ")
+	default:
+		national = trunk + strings.Join(groups, " ")
+	}
+	return national + p.extension(" ext. ")
+}
+
+// RFC3966 returns the number as a tel URI, e.g. "tel:+44-20-7946-0321;ext=12".
+func (p PhoneNumber) RFC3966() string {
+	if p.Number == "" {
+		return ""
+	}
+	return "tel:+" + p.CallingCode + "-" + strings.Join(p.groups(), "-") + p.extension(";ext=")
+}
+
+// String returns the number in international format.
+func (p PhoneNumber) String() string {
+	return p.International()
+}
+
+func (p PhoneNumber) extension(separator string) string {
+	if p.Extension == "" {
+		return ""
+	}
+	return separator + p.Extension
+}
+
+// groups splits the national significant number like the pattern of the numbering plan it matches.
+func (p PhoneNumber) groups() []string {
+	for _, kind := range phoneKinds {
+		for _, pattern := range numberingPlans[p.Country].ranges[kind] {
+			tokens, _ := parsePattern(strings.Replace(pattern, " ", "", -1))
+			if !matchPattern(tokens, p.Number) {
+				continue
+			}
+			var groups []string
+			rest := p.Number
+			for _, group := range strings.Split(pattern, " ") {
+				tokens, _ := parsePattern(group)
+				width := patternWidth(tokens)
+				groups = append(groups, rest[:width])
+				rest = rest[width:]
+			}
+			return groups
+		}
+	}
+	return []string{p.Number}
+}
+
+// extensionSuffix matches the extensions written after a phone number, e.g. " ext. 12", " x12", "#12" or ";ext=12".
+var extensionSuffix = regexp.MustCompile(`(?i)\s*(?:;ext=|,|#|extension|ext\.?|x)\s*(\d{1,6})$`)
+
+// ParsePhoneNumber reads a phone number of the supplied 2-letter country code, written in international format,
+// starting with '+' or 00 and the calling code, in national format, with the trunk prefix, or as a tel URI.
+// Spaces, dashes, dots, slashes and parentheses are ignored, and an extension may follow the number.
+func ParsePhoneNumber(countrycode, number string) (PhoneNumber, error) {
+	countrycode = strings.ToUpper(countrycode)
+	plan, ok := numberingPlans[countrycode]
+	if !ok {
+		return PhoneNumber{}, fmt.Errorf("randomdata: unsupported phone country %q", countrycode)
+	}
+	p := PhoneNumber{Country: countrycode, CallingCode: strings.TrimPrefix(jsonData.CountryInfo[countrycode].CallingCode, "+")}
+	text := strings.TrimPrefix(strings.TrimSpace(number), "tel:")
+	if match := extensionSuffix.FindStringSubmatchIndex(text); match != nil {
+		p.Extension = text[match[2]:match[3]]
+		text = text[:match[0]]
+	}
+	if nsn, ok := nationalSignificantNumber(p.CallingCode, plan, text); ok {
+		for _, kind := range phoneKinds {
+			for _, pattern := range plan.ranges[kind] {
+				tokens, _ := parsePattern(strings.Replace(pattern, " ", "", -1))
+				if matchPattern(tokens, nsn) {
+					p.Number, p.Kind = nsn, kind
+					return p, nil
+				}
+			}
+		}
+	}
+	return PhoneNumber{}, fmt.Errorf("randomdata: invalid phone number %q for %q", number, countrycode)
+}
+
+// ValidatePhoneNumber tells whether the number is a valid phone number of the supplied 2-letter country code.
+// See ParsePhoneNumber for the accepted formats.
+// It returns false for unsupported countries.
+func ValidatePhoneNumber(countrycode, number string) bool {
+	_, err := ParsePhoneNumber(countrycode, number)
+	return err == nil
+}
+
+// PhoneKindOf returns the kind of a phone number of the supplied 2-letter country code,
+// and whether the number is valid. See ParsePhoneNumber for the accepted formats.
+// Landlines and mobiles can't be told apart in the North American Numbering Plan: both are reported as Landline.
+func PhoneKindOf(countrycode, number string) (PhoneKind, bool) {
+	p, err := ParsePhoneNumber(countrycode, number)
+	return p.Kind, err == nil
+}
+
+// nationalSignificantNumber strips the formatting, the calling code and the trunk prefix of a number.
+func nationalSignificantNumber(callingCode string, plan numberingPlan, number string) (string, bool) {
+	number = strings.TrimSpace(number)
+	international := strings.HasPrefix(number, "+") || strings.HasPrefix(number, "00")
+	if international {
+		// Some countries show their trunk prefix in international numbers, e.g. "+49 (0)30 12345678".
+		number = strings.Replace(strings.TrimPrefix(number, "00"), "(0)", "", 1)
+	}
+	digits := stripSeparators(number, "+ -./()")
+	if !isDigits(digits) {
+		return "", false
+	}
+	if international {
+		if !strings.HasPrefix(digits, callingCode) {
+			return "", false
+		}
+		return digits[len(callingCode):], true
+	}
+	trunkless := func(nsn string) bool { return plan.trunkless != "" && strings.HasPrefix(nsn, plan.trunkless) }
+	if trunkless(digits) {
+		return digits, true
+	}
+	if strings.HasPrefix(digits, plan.trunk) {
+		return digits[len(plan.trunk):], !trunkless(digits[len(plan.trunk):])
+	}
+	return digits, plan.trunkOptional
+}
+
+// MessyPhoneNumber returns the phone number written the way people type it: with dots, dashes or parentheses,
+// an international prefix, missing or extra spaces, or an oddly written extension.
+// ParsePhoneNumber reads all of these variants.
+func (r *Rand) MessyPhoneNumber(p PhoneNumber) string {
+	if p.Number == "" {
+		return ""
+	}
+	plain := p.WithExtension("")
+	national := stripSeparators(plain.National(), "()")
+	groups := plain.groups()
+	var number string
+	switch r.Intn(8) {
+	case 0:
+		number = strings.NewReplacer(" ", ".", "-", ".").Replace(national)
+	case 1:
+		number = strings.Replace(national, " ", "-", -1)
+	case 2:
+		number = "00" + p.CallingCode + " " + strings.Join(groups, " ")
+	case 3:
+		number = plain.E164()
+	case 4:
+		number = stripSeparators(national, " -")
+	case 5:
+		number = "  +" + p.CallingCode + "  " + strings.Join(groups, "  ") + " "
+	case 6:
+		number = "+" + p.CallingCode + " (" + groups[0] + ") " + strings.Join(groups[1:], "-")
+	default:
+		number = "+" + p.CallingCode + "-" + strings.Join(groups, ".")
+	}
+	if p.Extension != "" {
+		number += r.StringFrom([]string{" x", " ext ", " ext. ", " #", ",", " Ext."}) + p.Extension
+	}
+	return number
+}
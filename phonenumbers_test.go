@@ -0,0 +1,146 @@
+package randomdata
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoneNumberFor(t *testing.T) {
+	r := FromSeed(1234)
+	for _, country := range SupportedPhoneCountries() {
+		info, _ := LookupCountry(country)
+		for _, kind := range phoneKinds {
+			if len(numberingPlans[country].ranges[kind]) == 0 {
+				assert.Equal(t, PhoneNumber{}, r.PhoneNumberFor(country, kind))
+				continue
+			}
+			for i := 0; i < 50; i++ {
+				number := r.PhoneNumberFor(country, kind).International()
+				assert.True(t, strings.HasPrefix(number, info.CallingCode+" "), "%q does not start with %q", number, info.CallingCode)
+				got, ok := PhoneKindOf(country, number)
+				assert.True(t, ok, "invalid %v number %q for %q", kind, number, country)
+				if info.CallingCode != "+1" || kind > Mobile {
+					assert.Equal(t, kind, got, "wrong kind for %q", number)
+				}
+				// E.164 numbers have at most 15 digits.
+				assert.LessOrEqual(t, len(stripSeparators(number, "+ ")), 15)
+			}
+		}
+	}
+	assert.Equal(t, PhoneNumber{}, r.PhoneNumberFor("XX", Mobile))
+	assert.Empty(t, r.PhoneNumberFor("IN", Premium).String())
+}
+
+func TestValidatePhoneNumber(t *testing.T) {
+	valid := map[string][]string{
+		"US": {"+1 212 555 0123", "(212) 555-0123", "212.555.0123", "1-800-555-0199", "+1 900 555 0100"},
+		"CA": {"+1 416 555 0123", "416-555-0123"},
+		"GB": {"+44 20 7946 0321", "020 7946 0321", "07700 900123", "+44 7700 900123", "0800 123 4567", "0161 496 0000"},
+		"DE": {"+49 30 12345678", "030 12345678", "0171 1234567", "0176 12345678", "+49 (0)800 1234567"},
+		"FR": {"+33 1 23 45 67 89", "01 23 45 67 89", "06 12 34 56 78", "07 81 23 45 67"},
+		"NL": {"+31 20 123 4567", "06-12345678", "0800 1234"},
+		"ES": {"+34 912 34 56 78", "612 34 56 78", "900 12 34 56"},
+		"IT": {"+39 06 1234 5678", "06 1234 5678", "+39 312 345 6789", "800 123456"},
+		"SE": {"+46 8 123 456 78", "08-123 456 78", "070-123 45 67"},
+		"BR": {"+55 11 91234 5678", "(11) 2345-6789", "011 91234 5678"},
+		"AU": {"+61 2 9374 4000", "02 9374 4000", "0412 345 678", "1800 123 456"},
+		"IN": {"+91 98765 43210", "098765 43210", "011 2345 6789", "1800 123 4567"},
+		"JP": {"+81 3 1234 5678", "03-1234-5678", "090-1234-5678", "0120-123-456"},
+	}
+	for country, numbers := range valid {
+		for _, number := range numbers {
+			assert.True(t, ValidatePhoneNumber(country, number), "%q should be valid for %q", number, country)
+		}
+	}
+
+	invalid := map[string][]string{
+		"US": {"+1 212 155 0123", "212 555 012", "+44 20 7946 0321", "212 555 0123 4"},
+		"GB": {"+44 20 1946 0321", "20 7946 0321", "07600 900123", "0800 123 45678", "020 7946 032a"},
+		"DE": {"+49 30 0234567", "0171 12345678"},
+		"FR": {"+33 0 23 45 67 89", "06 12 34 56 7"},
+		"AU": {"01800 123 456", "+61 5 9374 4000"},
+		"XX": {"+1 212 555 0123"},
+	}
+	for country, numbers := range invalid {
+		for _, number := range numbers {
+			assert.False(t, ValidatePhoneNumber(country, number), "%q should be invalid for %q", number, country)
+		}
+	}
+
+	kind, ok := PhoneKindOf("GB", "+44 7700 900123")
+	assert.True(t, ok)
+	assert.Equal(t, Mobile, kind)
+	kind, _ = PhoneKindOf("FR", "0891 12 34 56")
+	assert.Equal(t, Premium, kind)
+	assert.Equal(t, "toll-free", TollFree.String())
+}
+
+func TestPhoneNumberFormats(t *testing.T) {
+	tests := []struct {
+		country, number                        string
+		e164, international, national, rfc3966 string
+	}{
+		{"US", "+1 212 555 0123", "+12125550123", "+1 212 555 0123", "(212) 555-0123", "tel:+1-212-555-0123"},
+		{"GB", "020 7946 0321", "+442079460321", "+44 20 7946 0321", "020 7946 0321", "tel:+44-20-7946-0321"},
+		{"DE", "+49 30 12345678", "+493012345678", "+49 30 12345678", "030 12345678", "tel:+49-30-12345678"},
+		{"FR", "0612345678", "+33612345678", "+33 6 12 34 56 78", "06 12 34 56 78", "tel:+33-6-12-34-56-78"},
+		{"IT", "+39 06 1234 5678", "+390612345678", "+39 06 1234 5678", "06 1234 5678", "tel:+39-06-1234-5678"},
+		{"SE", "+46 8 123 456 78", "+46812345678", "+46 8 123 456 78", "08-123 456 78", "tel:+46-8-123-456-78"},
+		{"BR", "+55 11 91234 5678", "+5511912345678", "+55 11 91234 5678", "(11) 91234-5678", "tel:+55-11-91234-5678"},
+		{"BR", "(11) 2345-6789", "+551123456789", "+55 11 2345 6789", "(11) 2345-6789", "tel:+55-11-2345-6789"},
+		{"BR", "0800 123 4567", "+558001234567", "+55 800 123 4567", "0800 123 4567", "tel:+55-800-123-4567"},
+		{"BR", "+55 900 123 4567", "+559001234567", "+55 900 123 4567", "0900 123 4567", "tel:+55-900-123-4567"},
+		{"JP", "+81 90 1234 5678", "+819012345678", "+81 90 1234 5678", "090-1234-5678", "tel:+81-90-1234-5678"},
+		{"AU", "1800 123 456", "+611800123456", "+61 1800 123 456", "1800 123 456", "tel:+61-1800-123-456"},
+	}
+	for _, test := range tests {
+		p, err := ParsePhoneNumber(test.country, test.number)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.Equal(t, test.e164, p.E164())
+		assert.Equal(t, test.international, p.International())
+		assert.Equal(t, test.international, p.String())
+		assert.Equal(t, test.national, p.National())
+		assert.Equal(t, test.rfc3966, p.RFC3966())
+	}
+
+	p, err := ParsePhoneNumber("GB", "tel:+44-20-7946-0321;ext=42")
+	assert.NoError(t, err)
+	assert.Equal(t, PhoneNumber{Country: "GB", CallingCode: "44", Number: "2079460321", Kind: Landline, Extension: "42"}, p)
+	assert.Equal(t, "+44 20 7946 0321 ext. 42", p.International())
+	assert.Equal(t, "020 7946 0321 ext. 42", p.National())
+	assert.Equal(t, "tel:+44-20-7946-0321;ext=42", p.RFC3966())
+	assert.Equal(t, "+442079460321", p.E164())
+	assert.Equal(t, "", p.WithExtension("").extension(" ext. "))
+
+	assert.Empty(t, PhoneNumber{}.E164())
+	assert.Empty(t, PhoneNumber{}.National())
+	assert.Empty(t, PhoneNumber{}.RFC3966())
+	_, err = ParsePhoneNumber("XX", "+1 212 555 0123")
+	assert.Error(t, err)
+}
+
+func TestMessyPhoneNumber(t *testing.T) {
+	r := FromSeed(1234)
+	assert.Empty(t, r.MessyPhoneNumber(PhoneNumber{}))
+	variants := map[string]bool{}
+	for _, country := range SupportedPhoneCountries() {
+		for i := 0; i < 100; i++ {
+			p := r.PhoneNumberFor(country, phoneKinds[r.Intn(2)])
+			if r.Boolean() {
+				p = p.WithExtension(r.Digits(3))
+			}
+			messy := r.MessyPhoneNumber(p)
+			variants[messy] = true
+			parsed, err := ParsePhoneNumber(country, messy)
+			if assert.NoError(t, err, "could not parse %q", messy) {
+				assert.Equal(t, p.E164(), parsed.E164(), "%q was not read back", messy)
+				assert.Equal(t, p.Extension, parsed.Extension, "%q was not read back", messy)
+			}
+		}
+	}
+	assert.Greater(t, len(variants), 1000)
+}
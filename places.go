@@ -0,0 +1,107 @@
+package randomdata
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Place is a city together with its region, country, postal code and coordinates.
+// All the fields of a Place are consistent with each other.
+type Place struct {
+	City        string  `json:"city"`
+	Region      string  `json:"region"`      // as written in addresses, may be empty
+	Subdivision string  `json:"subdivision"` // ISO 3166-2 code, e.g. "US-TX"
+	Country     string  `json:"country"`     // 2-letter country code
+	PostalCode  string  `json:"postalCode"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+}
+
+// postalCompletions turn a gazetteer postal range or prefix into a full postal code.
+// Countries without a completion use the plain number drawn from the range.
+var postalCompletions = map[string]func(r *Rand, code string) string{
+	// GB outward code followed by the inward code.
+	"GB": func(r *Rand, outward string) string {
+		return outward + " " + r.Digits(1) + r.lettersFrom("ABDEFGHJLNPQRSTUWXYZ", 2)
+	},
+	// CA forward sortation area followed by the local delivery unit.
+	"CA": func(r *Rand, fsa string) string {
+		return fsa + " " + r.Digits(1) + r.lettersFrom("ABCEGHJKLMNPRSTVWXYZ", 1) + r.Digits(1)
+	},
+	"NL": func(r *Rand, digits string) string {
+		for {
+			letters := r.lettersFrom("ABCDEGHJKLMNPRSTVWXZ", 2)
+			if letters != "SA" && letters != "SD" && letters != "SS" {
+				return digits + " " + letters
+			}
+		}
+	},
+	"SE": func(r *Rand, digits string) string {
+		return digits[:3] + " " + digits[3:]
+	},
+	"BR": func(r *Rand, digits string) string {
+		return digits + "-" + r.Digits(3)
+	},
+}
+
+// Place returns a random place in the supplied 2-letter country code.
+// The postal code is within the range used by the city, e.g. a ZIP code matching the state in the US,
+// or a postcode of the post town in the UK.
+// If the country is not supported it will return an empty Place.
+func (r *Rand) Place(countrycode string) Place {
+	countrycode = strings.ToUpper(countrycode)
+	entries := jsonData.Gazetteer[countrycode]
+	if len(entries) == 0 {
+		return Place{}
+	}
+	e := entries[r.Intn(len(entries))]
+	code := r.postalCodeInRange(r.StringFrom(e.Postal))
+	if complete, ok := postalCompletions[countrycode]; ok {
+		code = complete(r, code)
+	}
+	return Place{
+		City:        e.City,
+		Region:      e.Region,
+		Subdivision: e.Subdivision,
+		Country:     countrycode,
+		PostalCode:  code,
+		Latitude:    e.Lat,
+		Longitude:   e.Lng,
+	}
+}
+
+// SupportedPlaceCountries returns the sorted 2-letter codes of the countries supported by Place.
+func SupportedPlaceCountries() []string {
+	countries := make([]string, 0, len(jsonData.Gazetteer))
+	for country := range jsonData.Gazetteer {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
+}
+
+// postalCodeInRange returns a number within a range such as "10001-10282", padded to the width of the range.
+// Anything else is returned as is.
+func (r *Rand) postalCodeInRange(spec string) string {
+	bounds := strings.SplitN(spec, "-", 2)
+	if len(bounds) != 2 {
+		return spec
+	}
+	low, err1 := strconv.Atoi(bounds[0])
+	high, err2 := strconv.Atoi(bounds[1])
+	if err1 != nil || err2 != nil || high < low {
+		return spec
+	}
+	return fmt.Sprintf("%0*d", len(bounds[0]), r.Number(low, high+1))
+}
+
+// lettersFrom generates a string of n letters picked from the supplied set.
+func (r *Rand) lettersFrom(set string, n int) string {
+	list := make([]byte, n)
+	for i := range list {
+		list[i] = set[r.Intn(len(set))]
+	}
+	return string(list)
+}
@@ -0,0 +1,93 @@
+package randomdata
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var placePostalFormats = map[string]*regexp.Regexp{
+	"US": regexp.MustCompile(`^\d{5}$`),
+	"GB": regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]? \d[ABD-HJLNP-UW-Z]{2}$`),
+	"CA": regexp.MustCompile(`^[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z] \d[ABCEGHJ-NPRSTV-Z]\d$`),
+	"AU": regexp.MustCompile(`^\d{4}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"NL": regexp.MustCompile(`^[1-9]\d{3} [A-Z]{2}$`),
+	"ES": regexp.MustCompile(`^\d{5}$`),
+	"IT": regexp.MustCompile(`^\d{5}$`),
+	"SE": regexp.MustCompile(`^\d{3} \d{2}$`),
+	"BR": regexp.MustCompile(`^\d{5}-\d{3}$`),
+}
+
+func TestPlace(t *testing.T) {
+	r := FromSeed(1234)
+	assert.Len(t, SupportedPlaceCountries(), len(placePostalFormats))
+
+	for _, country := range SupportedPlaceCountries() {
+		for i := 0; i < 200; i++ {
+			p := r.Place(country)
+			assert.Equal(t, country, p.Country)
+			assert.NotEmpty(t, p.City)
+			assert.NotEmpty(t, subdivisionName(p.Subdivision), "unknown subdivision %q", p.Subdivision)
+			assert.True(t, strings.HasPrefix(p.Subdivision, country+"-"))
+			assert.Regexp(t, placePostalFormats[country], p.PostalCode, "invalid postal code for country %q", country)
+			assert.InDelta(t, 0, p.Latitude, 90)
+			assert.InDelta(t, 0, p.Longitude, 180)
+		}
+	}
+	assert.Equal(t, Place{}, r.Place("bogus"), "did not return empty place for unknown country")
+}
+
+func TestPlaceConsistency(t *testing.T) {
+	r := FromSeed(1234)
+	// ZIP code prefixes of a few states.
+	zipPrefixes := map[string][2]int{
+		"US-NY": {100, 149}, "US-CA": {900, 961}, "US-IL": {600, 629}, "US-TX": {750, 799}, "US-WA": {980, 994},
+		"US-MA": {10, 27}, "US-FL": {320, 349},
+	}
+	for i := 0; i < 1000; i++ {
+		p := r.Place("US")
+		prefix, _ := strconv.Atoi(p.PostalCode[:3])
+		if bounds, ok := zipPrefixes[p.Subdivision]; ok {
+			assert.True(t, prefix >= bounds[0] && prefix <= bounds[1], "ZIP code %s does not match %s", p.PostalCode, p.Subdivision)
+		}
+	}
+
+	for i := 0; i < 1000; i++ {
+		p := r.Place("GB")
+		var outwards []string
+		for _, e := range jsonData.Gazetteer["GB"] {
+			if e.City == p.City {
+				outwards = e.Postal
+			}
+		}
+		assert.Contains(t, outwards, strings.Fields(p.PostalCode)[0], "postcode %s does not match %s", p.PostalCode, p.City)
+		// London's W1 postal district is split into sectors such as W1A or W1D, W1 alone is not an outward code.
+		assert.NotEqual(t, "W1", strings.Fields(p.PostalCode)[0])
+	}
+
+	// French cities have a handful of postal codes, not whole ranges of the département.
+	for i := 0; i < 1000; i++ {
+		p := r.Place("FR")
+		if p.City == "Toulouse" {
+			assert.Contains(t, []string{"31000", "31100", "31200", "31300", "31400", "31500"}, p.PostalCode)
+		}
+	}
+}
+
+func TestPostalCodeInRange(t *testing.T) {
+	r := FromSeed(1234)
+	for i := 0; i < 100; i++ {
+		code := r.postalCodeInRange("01067-01328")
+		n, err := strconv.Atoi(code)
+		assert.NoError(t, err)
+		assert.Len(t, code, 5)
+		assert.True(t, n >= 1067 && n <= 1328, "postal code %s out of range", code)
+	}
+	assert.Equal(t, "75001", r.postalCodeInRange("75001-75001"))
+	assert.Equal(t, "SW1A", r.postalCodeInRange("SW1A"))
+}
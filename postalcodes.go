@@ -1,208 +1,290 @@
 package randomdata
 
 import (
+	"fmt"
+	"sort"
 	"strings"
+	"sync"
 )
 
 // Supported formats obtained from:
 // * http://www.geopostcodes.com/GeoPC_Postal_codes_formats
+// * https://www.upu.int/en/Postal-Solutions/Programmes-Services/Addressing-Solutions (postal addressing systems)
+//
+// Each country has one or more patterns, see pattern.go, a code being generated from one of them picked at random.
+// A pattern starting with '!' describes codes that must be neither generated nor accepted.
+
+const (
+	// Letters used in the inward code of UK postcodes.
+	gbInward = "#[ABD-HJLNP-UW-Z][ABD-HJLNP-UW-Z]"
+	// Letters used in Canadian postal codes, the first one being narrower.
+	caFirst = "[ABCEGHJ-NPRSTVXY]"
+	caOther = "[ABCEGHJ-NPRSTV-Z]"
+	// Letters used in Dutch postcodes: F, I, O, Q, U and Y are not used.
+	nlLetter = "[A-EGHJ-NPRSTVWXZ]"
+)
+
+var postalFormats = map[string][]string{
+	"AD": {"AD[1-7]00"},
+	"AF": {"{10-43}{01-99}"},
+	"AL": {"####"},
+	"AM": {"####"},
+	"AR": {"[A-HJ-NP-Z]####@@@"},
+	"AT": {"{1010-9992}"},
+	"AU": {"{0200-9999}"},
+	"AZ": {"AZ ####"},
+	"BA": {"#####"},
+	"BB": {"BB#####"},
+	"BD": {"####"},
+	"BE": {"{1000-9999}"},
+	"BG": {"{1000-9999}"},
+	"BL": {"97133"},
+	"BM": {"@@ ##", "@@ @@"},
+	"BN": {"[BKPT]@####"},
+	"BR": {"#####-###"},
+	"BY": {"2#####"},
+	"CA": {caFirst + "#" + caOther + " #" + caOther + "#"},
+	"CH": {"{1000-9999}"},
+	"CL": {"#######"},
+	"CN": {"######"},
+	"CR": {"#####"},
+	"CU": {"CP {10000-99999}"},
+	"CV": {"####"},
+	"CY": {"{1000-9999}"},
+	"CZ": {"[1-7]## ##"},
+	"DE": {"{01000-99999}"},
+	"DK": {"{1000-9999}"},
+	"DO": {"#####"},
+	"DZ": {"#####"},
+	"EC": {"######"},
+	"EE": {"#####"},
+	"EG": {"#####"},
+	"EH": {"#####"},
+	"ES": {"{01-52}###"},
+	"ET": {"{1000-9999}"},
+	"FI": {"####[015]"},
+	"FK": {"FIQQ 1ZZ"},
+	"FM": {"FM{96941-96944}"},
+	"FO": {"FO ###"},
+	"FR": {"{01000-98999}"},
+	"GB": {
+		"[A-PR-UWYZ]# " + gbInward,
+		"[A-PR-UWYZ]## " + gbInward,
+		"[A-PR-UWYZ][A-HK-Y]# " + gbInward,
+		"[A-PR-UWYZ][A-HK-Y]## " + gbInward,
+		"[A-PR-UWYZ]#[A-HJKPSTUW] " + gbInward,
+		"[A-PR-UWYZ][A-HK-Y]#[ABEHMNPRVWXY] " + gbInward,
+	},
+	"GE": {"####"},
+	"GF": {"973##"},
+	"GG": {"GY[1-9] " + gbInward, "GY10 " + gbInward},
+	"GL": {"39##"},
+	"GP": {"971##"},
+	"GR": {"[1-8]## ##"},
+	"GT": {"#####"},
+	"GW": {"{1000-9999}"},
+	"HN": {"#####"},
+	"HR": {"HR-#####"},
+	"HT": {"HT####"},
+	"HU": {"{1000-9999}"},
+	"ID": {"#####"},
+	"IE": {"[AC-FHKNPRTV-Y]## [AC-FHKNPRTV-Y0-9][AC-FHKNPRTV-Y0-9][AC-FHKNPRTV-Y0-9][AC-FHKNPRTV-Y0-9]"},
+	"IL": {"#######"},
+	"IM": {"IM[1-9] " + gbInward},
+	"IN": {"[1-9]#####"},
+	"IQ": {"#####"},
+	"IR": {"##########"},
+	"IS": {"###"},
+	"IT": {"{00010-99999}"},
+	"JE": {"JE[1-5] " + gbInward},
+	"JM": {"JM@@@##"},
+	"JO": {"#####"},
+	"JP": {"###-####"},
+	"KE": {"{00100-99999}"},
+	"KG": {"######"},
+	"KH": {"#####"},
+	"KR": {"#####"},
+	"KW": {"#####"},
+	"KY": {"KY#-####"},
+	"KZ": {"######"},
+	"LA": {"{01000-99999}"},
+	"LB": {"#### ####"},
+	"LI": {"{9485-9498}"},
+	"LK": {"#####"},
+	"LR": {"{1000-9999}"},
+	"LS": {"###"},
+	"LT": {"LT-#####"},
+	"LU": {"{1000-9999}"},
+	"LV": {"LV-####"},
+	"MA": {"#####"},
+	"MC": {"980##"},
+	"MD": {"MD-{1000-9999}"},
+	"ME": {"8####"},
+	"MF": {"97150"},
+	"MG": {"###"},
+	"MH": {"{96960-96970}"},
+	"MK": {"{1000-9999}"},
+	"MM": {"{01-14}###"},
+	"MN": {"#####"},
+	"MQ": {"972##"},
+	"MT": {"@@@ ####"},
+	"MV": {"#####"},
+	"MX": {"#####"},
+	"MY": {"#####"},
+	"MZ": {"####"},
+	"NC": {"988##"},
+	"NE": {"####"},
+	"NG": {"######"},
+	"NI": {"#####"},
+	"NL": {"[1-9]### " + nlLetter + nlLetter, "![1-9]### S[ADS]"},
+	"NO": {"####"},
+	"NP": {"{10700-56311}"},
+	"NZ": {"####"},
+	"OM": {"###"},
+	"PE": {"#####"},
+	"PF": {"987##"},
+	"PG": {"###"},
+	"PH": {"{1000-9999}"},
+	"PK": {"#####"},
+	"PL": {"##-###"},
+	"PM": {"97500"},
+	"PR": {"00[679]##"},
+	"PT": {"[1-9]###-###"},
+	"PW": {"96940"},
+	"PY": {"####"},
+	"RE": {"974##"},
+	"RO": {"######"},
+	"RS": {"#####"},
+	"RU": {"######"},
+	"SA": {"{10000-99999}-{1000-9999}"},
+	"SD": {"#####"},
+	"SE": {"[1-9]## ##"},
+	"SG": {"######"},
+	"SI": {"SI-{1000-9999}"},
+	"SJ": {"####"},
+	"SK": {"[089]## ##"},
+	"SM": {"4789#"},
+	"SV": {"CP {1000-9999}"},
+	"SZ": {"[HLMS]###"},
+	"TC": {"TKCA 1ZZ"},
+	"TH": {"{10000-96999}"},
+	"TJ": {"######"},
+	"TM": {"######"},
+	"TN": {"{1000-9999}"},
+	"TR": {"{01000-81999}"},
+	"TW": {"#####"},
+	"UA": {"#####"},
+	"US": {"#####"},
+	"UY": {"#####"},
+	"UZ": {"######"},
+	"VA": {"00120"},
+	"VE": {"{1000-9999}"},
+	"VG": {"VG11[1-6]0"},
+	"VN": {"######"},
+	"WF": {"986##"},
+	"XK": {"#####"},
+	"YT": {"976##"},
+	"ZA": {"####"},
+	"ZM": {"#####"},
+}
+
+var postalFormatsMu sync.RWMutex
+
+// maxPatternRetries bounds the number of codes drawn until one is not excluded.
+// Registration rejects the exclusions covering a whole pattern, but not those covering it together.
+const maxPatternRetries = 1000
 
 // PostalCode yields a random postal/zip code for the given 2-letter country code.
 //
 // These codes are not guaranteed to refer to actually locations.
 // They merely follow the correct format as far as letters and digits goes.
 // Where possible, the function enforces valid ranges of letters and digits.
+// If the country is not supported, or its exclusions leave no code to generate, it will return an empty string.
 func (r *Rand) PostalCode(countrycode string) string {
-	switch strings.ToUpper(countrycode) {
-	case "LS", "MG", "IS", "OM", "PG":
-		return r.Digits(3)
-
-	case "AM", "GE", "NZ", "NE", "NO", "PY", "ZA", "MZ", "SJ", "LI", "AL",
-		"BD", "CV", "GL":
-		return r.Digits(4)
-
-	case "DZ", "BA", "KH", "DO", "EG", "EE", "GP", "GT", "ID", "IL", "JO",
-		"KW", "MQ", "MX", "LK", "SD", "TR", "UA", "US", "CR", "IQ", "KV", "MY",
-		"MN", "ME", "PK", "SM", "MA", "UY", "EH", "ZM":
-		return r.Digits(5)
-
-	case "BY", "CN", "IN", "KZ", "KG", "NG", "RO", "RU", "SG", "TJ", "TM", "UZ", "VN":
-		return r.Digits(6)
-
-	case "CL":
-		return r.Digits(7)
-
-	case "IR":
-		return r.Digits(10)
-
-	case "FO":
-		return "FO " + r.Digits(3)
-
-	case "AF":
-		return r.BoundedDigits(2, 10, 43) + r.BoundedDigits(2, 1, 99)
-
-	case "AU", "AT", "BE", "BG", "CY", "DK", "ET", "GW", "HU", "LR", "MK", "PH",
-		"CH", "TN", "VE":
-		return r.BoundedDigits(4, 1000, 9999)
-
-	case "SV":
-		return "CP " + r.BoundedDigits(4, 1000, 9999)
-
-	case "HT":
-		return "HT" + r.Digits(4)
-
-	case "LB":
-		return r.Digits(4) + " " + r.Digits(4)
-
-	case "LU":
-		return r.BoundedDigits(4, 6600, 6999)
-
-	case "MD":
-		return "MD-" + r.BoundedDigits(4, 1000, 9999)
-
-	case "HR":
-		return "HR-" + r.Digits(5)
-
-	case "CU":
-		return "CP " + r.BoundedDigits(5, 10000, 99999)
-
-	case "FI":
-		// Last digit is usually 0 but can, in some cases, be 1 or 5.
-		switch r.Intn(2) {
-		case 0:
-			return r.Digits(4) + "0"
-		case 1:
-			return r.Digits(4) + "1"
+	allowed, excluded := postalPatterns(countrycode)
+	if len(allowed) == 0 {
+		return ""
+	}
+	for i := 0; i < maxPatternRetries; i++ {
+		code := r.fromPattern(allowed[r.Intn(len(allowed))])
+		if !matchAnyPattern(excluded, code) {
+			return code
 		}
+	}
+	return ""
+}
 
-		return r.Digits(4) + "5"
-
-	case "FR", "GF", "PF", "YT", "MC", "RE", "BL", "MF", "PM", "RS", "TH":
-		return r.BoundedDigits(5, 10000, 99999)
-
-	case "DE":
-		return r.BoundedDigits(5, 1000, 99999)
-
-	case "GR":
-		return r.BoundedDigits(3, 100, 999) + " " + r.Digits(2)
-
-	case "HN":
-		return "CM" + r.Digits(4)
-
-	case "IT", "VA":
-		return r.BoundedDigits(5, 10, 99999)
-
-	case "KE":
-		return r.BoundedDigits(5, 100, 99999)
-
-	case "LA":
-		return r.BoundedDigits(5, 1000, 99999)
-
-	case "MH":
-		return r.BoundedDigits(5, 96960, 96970)
-
-	case "FM":
-		return "FM" + r.BoundedDigits(5, 96941, 96944)
-
-	case "MM":
-		return r.BoundedDigits(2, 1, 14) + r.Digits(3)
-
-	case "NP":
-		return r.BoundedDigits(5, 10700, 56311)
-
-	case "NC":
-		return "98" + r.Digits(3)
-
-	case "PW":
-		return "PW96940"
-
-	case "PR":
-		return "PR " + r.Digits(5)
-
-	case "SA":
-		return r.BoundedDigits(5, 10000, 99999) + "-" + r.BoundedDigits(4, 1000, 9999)
-
-	case "ES":
-		return r.BoundedDigits(2, 1, 52) + r.BoundedDigits(3, 100, 999)
-
-	case "WF":
-		return "986" + r.Digits(2)
-
-	case "SZ":
-		return r.Letters(1) + r.Digits(3)
-
-	case "BM":
-		return r.Letters(2) + r.Digits(2)
-
-	case "AD":
-		return r.Letters(2) + r.Digits(3)
-
-	case "BN", "AZ", "VG", "PE":
-		return r.Letters(2) + r.Digits(4)
-
-	case "BB":
-		return r.Letters(2) + r.Digits(5)
-
-	case "EC":
-		return r.Letters(2) + r.Digits(6)
-
-	case "MT":
-		return r.Letters(3) + r.Digits(4)
-
-	case "JM":
-		return "JM" + r.Letters(3) + r.Digits(2)
-
-	case "AR":
-		return r.Letters(1) + r.Digits(4) + r.Letters(3)
-
-	case "CA":
-		return r.Letters(1) + r.Digits(1) + r.Letters(1) + r.Digits(1) + r.Letters(1) + r.Digits(1)
-
-	case "FK", "TC":
-		return r.Letters(4) + r.Digits(1) + r.Letters(2)
-
-	case "GG", "IM", "JE":
-		return r.Letters(2) + r.Digits(2) + r.Letters(2)
-
-	case "GB":
-		return r.Letters(2) + r.Digits(1) + " " + r.Digits(1) + r.Letters(2)
-
-	case "KY":
-		return r.Letters(2) + r.Digits(1) + "-" + r.Digits(4)
-
-	case "JP":
-		return r.Digits(3) + "-" + r.Digits(4)
-
-	case "LV", "SI":
-		return r.Letters(2) + "-" + r.Digits(4)
-
-	case "LT":
-		return r.Letters(2) + "-" + r.Digits(5)
-
-	case "SE", "TW":
-		return r.Digits(5)
-
-	case "MV":
-		return r.Digits(2) + "-" + r.Digits(2)
-
-	case "PL":
-		return r.Digits(2) + "-" + r.Digits(3)
-
-	case "NI":
-		return r.Digits(3) + "-" + r.Digits(3) + "-" + r.Digits(1)
-
-	case "KR":
-		return r.Digits(3) + "-" + r.Digits(3)
-
-	case "PT":
-		return r.Digits(4) + "-" + r.Digits(3)
+// ValidatePostalCode tells whether the code follows the postal code format of the given 2-letter country code.
+// Letters are accepted in any case, spaces and dashes must be where the format puts them.
+// It returns false for unsupported countries.
+func ValidatePostalCode(countrycode, code string) bool {
+	allowed, excluded := postalPatterns(countrycode)
+	code = strings.ToUpper(strings.TrimSpace(code))
+	return matchAnyPattern(allowed, code) && !matchAnyPattern(excluded, code)
+}
 
-	case "NL":
-		return r.Digits(4) + r.Letters(2)
+// SupportedPostalCountries returns the sorted 2-letter codes of the countries supported by PostalCode.
+func SupportedPostalCountries() []string {
+	postalFormatsMu.RLock()
+	defer postalFormatsMu.RUnlock()
+	countries := make([]string, 0, len(postalFormats))
+	for country := range postalFormats {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
+}
 
-	case "BR":
-		return r.Digits(5) + "-" + r.Digits(3)
+// RegisterPostalFormat sets the postal code patterns of the given 2-letter country code,
+// replacing the built-in ones if any. See pattern.go for the syntax of patterns.
+func RegisterPostalFormat(countrycode string, patterns ...string) error {
+	countrycode = strings.ToUpper(countrycode)
+	if len(countrycode) != 2 || !isLetters(countrycode) {
+		return fmt.Errorf("randomdata: invalid country code %q", countrycode)
+	}
+	var allowed, excluded []string
+	for _, pattern := range patterns {
+		if _, err := parsePattern(strings.TrimPrefix(pattern, "!")); err != nil {
+			return err
+		}
+		if strings.HasPrefix(pattern, "!") {
+			excluded = append(excluded, pattern)
+		} else {
+			allowed = append(allowed, pattern)
+		}
+	}
+	if len(allowed) == 0 {
+		return fmt.Errorf("randomdata: no postal code pattern for %q", countrycode)
+	}
+	for _, pattern := range allowed {
+		tokens, _ := parsePattern(pattern)
+		for _, exclusion := range excluded {
+			if excludedTokens, _ := parsePattern(exclusion[1:]); patternCovers(excludedTokens, tokens) {
+				return fmt.Errorf("randomdata: postal code pattern %q of %q is excluded by %q", pattern, countrycode, exclusion)
+			}
+		}
 	}
+	postalFormatsMu.Lock()
+	defer postalFormatsMu.Unlock()
+	postalFormats[countrycode] = append([]string(nil), patterns...)
+	return nil
+}
 
-	return ""
+// postalPatterns returns the parsed patterns of a country, split between allowed and excluded ones.
+func postalPatterns(countrycode string) (allowed, excluded [][]patternToken) {
+	postalFormatsMu.RLock()
+	patterns := postalFormats[strings.ToUpper(countrycode)]
+	postalFormatsMu.RUnlock()
+	for _, pattern := range patterns {
+		tokens, err := parsePattern(strings.TrimPrefix(pattern, "!"))
+		if err != nil {
+			continue
+		}
+		if strings.HasPrefix(pattern, "!") {
+			excluded = append(excluded, tokens)
+		} else {
+			allowed = append(allowed, tokens)
+		}
+	}
+	return allowed, excluded
 }
@@ -2,6 +2,7 @@ package randomdata
 
 import (
 	"regexp"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,34 +12,34 @@ var postalcodeTests = []struct {
 	Country string
 	Size    int
 }{
-	{"PE", 6},
+	{"PE", 5},
 	{"FO", 6},
 	{"AF", 4},
 	{"DZ", 5},
 	{"BY", 6},
 	{"CL", 7},
 	{"SZ", 4},
-	{"BM", 4},
+	{"BM", 5},
 	{"AD", 5},
 	{"BN", 6},
 	{"BB", 7},
-	{"MT", 7},
+	{"MT", 8},
 	{"JM", 7},
 	{"AR", 8},
-	{"CA", 6},
-	{"FK", 7},
-	{"GG", 6},
-	{"NL", 6},
+	{"CA", 7},
+	{"FK", 8},
+	{"GG", 7},
+	{"NL", 7},
 	{"BR", 9},
 	{"KY", 8},
 	{"JP", 8},
 	{"LV", 7},
 	{"LT", 8},
 	{"MV", 5},
-	{"NI", 9},
+	{"NI", 5},
 	{"PL", 6},
 	{"PT", 8},
-	{"KR", 7},
+	{"KR", 5},
 	{"TW", 5},
 	{"MH", 5},
 	{"GB", 7},
@@ -64,3 +65,122 @@ func TestPostalCodeFormat(t *testing.T) {
 		}
 	}
 }
+
+func TestPostalCodeValidates(t *testing.T) {
+	r := FromSeed(1234)
+	assert.Contains(t, SupportedPostalCountries(), "IE")
+	for _, country := range SupportedPostalCountries() {
+		for i := 0; i < 100; i++ {
+			code := r.PostalCode(country)
+			assert.True(t, ValidatePostalCode(country, code), "invalid postal code %q for country %q", code, country)
+		}
+	}
+	assert.Empty(t, r.PostalCode("bogus"))
+
+	for _, country := range SupportedPlaceCountries() {
+		for i := 0; i < 100; i++ {
+			p := r.Place(country)
+			assert.True(t, ValidatePostalCode(country, p.PostalCode), "invalid postal code %q for %s", p.PostalCode, p.City)
+		}
+	}
+}
+
+func TestPostalCodeLetters(t *testing.T) {
+	r := FromSeed(1234)
+	gb := regexp.MustCompile(`^[A-PR-UWYZ]([A-HK-Y]?\d[\dA-HJKPSTUW]?|[A-HK-Y]\d[ABEHMNPRVWXY]) \d[ABD-HJLNP-UW-Z]{2}$`)
+	ca := regexp.MustCompile(`^[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z] \d[ABCEGHJ-NPRSTV-Z]\d$`)
+	nl := regexp.MustCompile(`^[1-9]\d{3} [A-EGHJ-NPRSTVWXZ]{2}$`)
+	letters := map[byte]bool{}
+	for i := 0; i < 2000; i++ {
+		assert.Regexp(t, gb, r.PostalCode("GB"))
+		assert.Regexp(t, ca, r.PostalCode("CA"))
+		code := r.PostalCode("NL")
+		assert.Regexp(t, nl, code)
+		assert.NotContains(t, []string{"SA", "SD", "SS"}, code[5:])
+		letters[r.Letters(1)[0]] = true
+	}
+	assert.True(t, letters['Z'], "letter Z is never produced")
+	assert.Len(t, letters, 26)
+}
+
+func TestValidatePostalCode(t *testing.T) {
+	valid := map[string][]string{
+		"US": {"90210", "00501"},
+		"GB": {"SW1A 2AA", "EC1A 1BB", "W1A 0AX", "M1 1AE", "B33 8TH", "CR2 6XH", "DN55 1PT", "sw1a 2aa"},
+		"CA": {"K1A 0B1", "H0H 0H0", "V8W 9W5"},
+		"NL": {"1012 JS", "2514 EA"},
+		"DE": {"10117", "01067"},
+		"SE": {"111 30"},
+		"BR": {"01310-200"},
+		"JP": {"100-0001"},
+		"IE": {"D02 X285", "A65 F4E2"},
+		"PL": {"00-950"},
+		"PT": {"1000-001"},
+		"AR": {"C1425DKF"},
+		"FI": {"00100", "00101", "33105"},
+		"XK": {"10000"},
+	}
+	for country, codes := range valid {
+		for _, code := range codes {
+			assert.True(t, ValidatePostalCode(country, code), "%q should be valid for %q", code, country)
+		}
+	}
+	invalid := map[string][]string{
+		"US": {"9021", "902100", "ABCDE"},
+		"GB": {"QA1 1AA", "SW1A 1AI", "SW1A1AA", "V1 1AA"},
+		"CA": {"D1A 0B1", "K1A0B1", "K1A 0B"},
+		"NL": {"0123 AB", "1012 SA", "1012 FO", "1012AB"},
+		"DE": {"00999", "1011"},
+		"FI": {"00102", "0010"},
+		"KV": {"10000"},
+		"XX": {"12345"},
+	}
+	for country, codes := range invalid {
+		for _, code := range codes {
+			assert.False(t, ValidatePostalCode(country, code), "%q should be invalid for %q", code, country)
+		}
+	}
+}
+
+func TestRegisterPostalFormat(t *testing.T) {
+	defer func(patterns []string) { postalFormats["IS"] = patterns }(postalFormats["IS"])
+	r := FromSeed(1234)
+
+	assert.NoError(t, RegisterPostalFormat("is", "{101-902}", "!{500-599}"))
+	for i := 0; i < 500; i++ {
+		code := r.PostalCode("IS")
+		n, _ := strconv.Atoi(code)
+		assert.True(t, n >= 101 && n <= 902 && (n < 500 || n > 599), "code %q out of range", code)
+	}
+	assert.True(t, ValidatePostalCode("IS", "101"))
+	assert.False(t, ValidatePostalCode("IS", "100"))
+	assert.False(t, ValidatePostalCode("IS", "550"))
+
+	defer delete(postalFormats, "ZZ")
+	assert.NoError(t, RegisterPostalFormat("ZZ", "ZZ-[A-C]#"))
+	assert.Contains(t, SupportedPostalCountries(), "ZZ")
+	assert.Regexp(t, `^ZZ-[ABC]\d$`, r.PostalCode("ZZ"))
+
+	assert.Error(t, RegisterPostalFormat("ZZZ", "#"))
+	assert.Error(t, RegisterPostalFormat("ZZ"))
+	assert.Error(t, RegisterPostalFormat("ZZ", "!###"))
+	assert.Error(t, RegisterPostalFormat("ZZ", "[AB"))
+	assert.Error(t, RegisterPostalFormat("ZZ", "{10-9}"))
+	assert.Error(t, RegisterPostalFormat("ZZ", "{1-10}"))
+	assert.Error(t, RegisterPostalFormat("ZZ", "[Z-A]#"))
+	assert.Error(t, RegisterPostalFormat("ZZ", "[]#"))
+	assert.Error(t, RegisterPostalFormat("ZZ", "#", "!#"))
+	assert.Error(t, RegisterPostalFormat("ZZ", "[A-C]#", "!@{0-9}"))
+	assert.Error(t, RegisterPostalFormat("ZZ", "{10-20}", "!{10-20}"))
+	assert.Error(t, RegisterPostalFormat("ZZ", "{10-20}", "!##"))
+
+	// Exclusions covering a pattern together are not detected, but generation gives up.
+	assert.NoError(t, RegisterPostalFormat("ZZ", "[AB]", "!A", "!B"))
+	assert.Equal(t, "", r.PostalCode("ZZ"))
+
+	// Sets may range up to the last byte.
+	tokens, err := parsePattern("[\xf0-\xff]")
+	if assert.NoError(t, err) {
+		assert.Len(t, tokens[0].set, 16)
+	}
+}
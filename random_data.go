@@ -15,12 +15,42 @@ import (
 	"unicode"
 )
 
+// Gender decides the gender of generated names, titles and profiles.
+type Gender int
+
 const (
-	Male         int = 0
-	Female       int = 1
-	RandomGender int = 2
+	Male         Gender = 0
+	Female       Gender = 1
+	RandomGender Gender = 2
+	NonBinary    Gender = 3
 )
 
+// String returns the lower case name of the gender.
+func (g Gender) String() string {
+	switch g {
+	case Male:
+		return "male"
+	case Female:
+		return "female"
+	case NonBinary:
+		return "nonbinary"
+	case RandomGender:
+		return "random"
+	}
+	return "Gender(" + strconv.Itoa(int(g)) + ")"
+}
+
+// GenderRatio holds the relative weights used to pick a gender at random.
+// The weights do not need to add up to one.
+type GenderRatio struct {
+	Male      float64
+	Female    float64
+	NonBinary float64
+}
+
+// DefaultGenderRatio is the gender ratio used when none is supplied.
+var DefaultGenderRatio = GenderRatio{Male: 0.49, Female: 0.49, NonBinary: 0.02}
+
 const (
 	Small int = 0
 	Large int = 1
@@ -40,33 +70,69 @@ const (
 const alphanumeric = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 
 type jsonContent struct {
-	Adjectives          []string `json:"adjectives"`
-	Nouns               []string `json:"nouns"`
-	FirstNamesFemale    []string `json:"firstNamesFemale"`
-	FirstNamesMale      []string `json:"firstNamesMale"`
-	LastNames           []string `json:"lastNames"`
-	Domains             []string `json:"domains"`
-	People              []string `json:"people"`
-	StreetTypes         []string `json:"streetTypes"` // Taken from https://github.com/tomharris/random_data/blob/master/lib/random_data/locations.rb
-	Paragraphs          []string `json:"paragraphs"`  // Taken from feedbooks.com and www.gutenberg.org
-	Countries           []string `json:"countries"`   // Fetched from the world bank at http://siteresources.worldbank.org/DATASTATISTICS/Resources/CLASS.XLS
-	CountriesThreeChars []string `json:"countriesThreeChars"`
-	CountriesTwoChars   []string `json:"countriesTwoChars"`
-	Currencies          []string `json:"currencies"` //https://github.com/OpenBookPrices/country-data
-	Cities              []string `json:"cities"`
-	States              []string `json:"states"`
-	StatesSmall         []string `json:"statesSmall"`
-	Days                []string `json:"days"`
-	Months              []string `json:"months"`
-	FemaleTitles        []string `json:"femaleTitles"`
-	MaleTitles          []string `json:"maleTitles"`
-	Timezones           []string `json:"timezones"`           // https://en.wikipedia.org/wiki/List_of_tz_database_time_zones
-	Locales             []string `json:"locales"`             // https://tools.ietf.org/html/bcp47
-	UserAgents          []string `json:"userAgents"`          // http://techpatterns.com/downloads/firefox/useragentswitcher.xml
-	CountryCallingCodes []string `json:"countryCallingCodes"` // from https://github.com/datasets/country-codes/blob/master/data/country-codes.csv
-	ProvincesGB         []string `json:"provincesGB"`
-	StreetNameGB        []string `json:"streetNameGB"`
-	StreetTypesGB       []string `json:"streetTypesGB"`
+	Adjectives           []string                    `json:"adjectives"`
+	Nouns                []string                    `json:"nouns"`
+	FirstNamesFemale     []string                    `json:"firstNamesFemale"`
+	FirstNamesMale       []string                    `json:"firstNamesMale"`
+	LastNames            []string                    `json:"lastNames"`
+	SurnameParticles     []string                    `json:"surnameParticles"`
+	GenerationalSuffixes []string                    `json:"generationalSuffixes"`
+	ProfessionalSuffixes []string                    `json:"professionalSuffixes"`
+	Nicknames            map[string][]string         `json:"nicknames"`
+	Domains              []string                    `json:"domains"`
+	People               []string                    `json:"people"`
+	StreetTypes          []string                    `json:"streetTypes"` // Taken from https://github.com/tomharris/random_data/blob/master/lib/random_data/locations.rb
+	Industries           []string                    `json:"industries"`
+	CompanyWords         []string                    `json:"companyWords"`
+	Paragraphs           []string                    `json:"paragraphs"` // Taken from feedbooks.com and www.gutenberg.org
+	Countries            []string                    `json:"countries"`  // Fetched from the world bank at http://siteresources.worldbank.org/DATASTATISTICS/Resources/CLASS.XLS
+	CountriesThreeChars  []string                    `json:"countriesThreeChars"`
+	CountriesTwoChars    []string                    `json:"countriesTwoChars"`
+	Currencies           []string                    `json:"currencies"` //https://github.com/OpenBookPrices/country-data
+	Cities               []string                    `json:"cities"`
+	Gazetteer            map[string][]gazetteerEntry `json:"gazetteer"`
+	Streets              map[string]streetNames      `json:"streets"`
+	States               []string                    `json:"states"`
+	StatesSmall          []string                    `json:"statesSmall"`
+	Days                 []string                    `json:"days"`
+	Months               []string                    `json:"months"`
+	FirstNamesNeutral    []string                    `json:"firstNamesNeutral"`
+	FemaleTitles         []string                    `json:"femaleTitles"`
+	MaleTitles           []string                    `json:"maleTitles"`
+	NeutralTitles        []string                    `json:"neutralTitles"`
+	Timezones            []string                    `json:"timezones"`           // https://en.wikipedia.org/wiki/List_of_tz_database_time_zones
+	Locales              []string                    `json:"locales"`             // https://tools.ietf.org/html/bcp47
+	CountryCallingCodes  []string                    `json:"countryCallingCodes"` // from https://github.com/datasets/country-codes/blob/master/data/country-codes.csv
+	CountryInfo          map[string]countryEntry     `json:"countryInfo"`         // ISO 3166-1 codes from https://salsa.debian.org/iso-codes-team/iso-codes, time zones from the tz database zone.tab
+	Subdivisions         map[string][][3]string      `json:"subdivisions"`        // ISO 3166-2 code, name and type, from https://salsa.debian.org/iso-codes-team/iso-codes
+	ProvincesGB          []string                    `json:"provincesGB"`
+	StreetNameGB         []string                    `json:"streetNameGB"`
+	StreetTypesGB        []string                    `json:"streetTypesGB"`
+}
+
+type gazetteerEntry struct {
+	City        string   `json:"city"`
+	Region      string   `json:"region"`      // as written in addresses
+	Subdivision string   `json:"subdivision"` // ISO 3166-2 code
+	Postal      []string `json:"postal"`      // postal code ranges such as "10001-10282", or prefixes such as "SW1A"
+	Lat         float64  `json:"lat"`
+	Lng         float64  `json:"lng"`
+}
+
+type countryEntry struct {
+	Name        string   `json:"name"`
+	Alpha3      string   `json:"alpha3"`
+	Numeric     string   `json:"numeric"`
+	Currencies  []string `json:"currencies"` // ISO 4217, the most used first
+	CallingCode string   `json:"callingCode"`
+	Languages   []string `json:"languages"` // official languages, the most spoken first
+	Timezones   []string `json:"timezones"`
+}
+
+type streetNames struct {
+	Names   []string `json:"names"`
+	Types   []string `json:"types"`
+	Pattern string   `json:"pattern"` // %n is replaced by the name and %t by the type
 }
 
 var jsonData = jsonContent{}
@@ -128,6 +194,28 @@ func (r *Rand) Float64() float64 {
 	return r.pr.Float64()
 }
 
+// NormFloat64 returns a normally distributed float64 with a mean of 0 and a standard deviation of 1.
+func (r *Rand) NormFloat64() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.pr.NormFloat64()
+}
+
+// Read fills p with pseudo-random bytes. It always returns len(p) and a nil error,
+// so that a Rand can be used as an io.Reader.
+func (r *Rand) Read(p []byte) (n int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.pr.Read(p)
+}
+
+// randomBytes returns n random bytes.
+func (r *Rand) randomBytes(n int) []byte {
+	b := make([]byte, n)
+	r.Read(b)
+	return b
+}
+
 func (r *Rand) Decimal(numberRange ...int) float64 {
 	nr := 0.0
 	if len(numberRange) > 1 {
@@ -250,7 +338,7 @@ func (r *Rand) Alphanumeric(length int) string {
 func (r *Rand) Letters(letters int) string {
 	list := make([]byte, letters)
 	for i := range list {
-		list[i] = byte(r.Intn('Z'-'A') + 'A')
+		list[i] = byte(r.Intn('Z'-'A'+1) + 'A')
 	}
 	return string(list)
 }
@@ -307,28 +395,51 @@ func (r *Rand) StringNumber(numberPairs int, separator string) string {
 	return r.StringNumberExt(numberPairs, separator, 2)
 }
 
+// PickGender returns a random gender, weighted according to the supplied ratio.
+// If all the weights are zero it uses DefaultGenderRatio.
+func (r *Rand) PickGender(ratio GenderRatio) Gender {
+	total := ratio.Male + ratio.Female + ratio.NonBinary
+	if total <= 0 {
+		ratio = DefaultGenderRatio
+		total = ratio.Male + ratio.Female + ratio.NonBinary
+	}
+	x := r.Float64() * total
+	switch {
+	case x < ratio.Male:
+		return Male
+	case x < ratio.Male+ratio.Female:
+		return Female
+	}
+	return NonBinary
+}
+
 // Title returns a random title, gender decides the gender of the name.
-func (r *Rand) Title(gender int) string {
+func (r *Rand) Title(gender Gender) string {
 	switch gender {
 	case Male:
 		return r.StringFrom(jsonData.MaleTitles)
 	case Female:
 		return r.StringFrom(jsonData.FemaleTitles)
+	case NonBinary:
+		return r.StringFrom(jsonData.NeutralTitles)
 	default:
-		return r.Title(r.Intn(2))
+		return r.Title(Gender(r.Intn(2)))
 	}
 }
 
 // FirstName returns a random first name, gender decides the gender of the name.
-func (r *Rand) FirstName(gender int) string {
+// NonBinary yields a gender-neutral name.
+func (r *Rand) FirstName(gender Gender) string {
 	var name = ""
 	switch gender {
 	case Male:
 		name = r.StringFrom(jsonData.FirstNamesMale)
 	case Female:
 		name = r.StringFrom(jsonData.FirstNamesFemale)
+	case NonBinary:
+		name = r.StringFrom(jsonData.FirstNamesNeutral)
 	default:
-		name = r.FirstName(rand.Intn(2))
+		name = r.FirstName(Gender(r.Intn(2)))
 	}
 	return name
 }
@@ -339,17 +450,17 @@ func (r *Rand) LastName() string {
 }
 
 // FullName returns a combination of FirstName LastName randomized, gender decides the gender of the name.
-func (r *Rand) FullName(gender int) string {
+func (r *Rand) FullName(gender Gender) string {
 	return r.FirstName(gender) + " " + r.LastName()
 }
 
 // Email returns a random email.
 func (r *Rand) Email() string {
-	return r.createEmail(r.FirstName(RandomGender), r.LastName())
+	return r.EmailAddress(PlainEmail)
 }
 
 func (r *Rand) createEmail(firstName, lastName string) string {
-	return strings.ToLower(firstName+"."+lastName) + r.StringNumberExt(1, "", 3) + "@" + r.StringFrom(jsonData.Domains)
+	return emailAtom(firstName) + "." + emailAtom(lastName) + r.StringNumberExt(1, "", 3) + "@" + r.StringFrom(jsonData.Domains)
 }
 
 // Country returns a random country, countryStyle decides what kind of format the returned country will have.
@@ -378,6 +489,7 @@ func (r *Rand) City() string {
 }
 
 // ProvinceForCountry returns a randomly selected province (state, county,subdivision ) name for a supplied country.
+// US states and GB counties are picked from dedicated lists, other countries use their ISO 3166-2 subdivisions.
 // If the country is not supported it will return an empty string.
 func (r *Rand) ProvinceForCountry(countrycode string) string {
 	switch countrycode {
@@ -386,7 +498,7 @@ func (r *Rand) ProvinceForCountry(countrycode string) string {
 	case "GB":
 		return r.StringFrom(jsonData.ProvincesGB)
 	}
-	return ""
+	return r.SubdivisionForCountry(countrycode).Name
 }
 
 // State returns a random american state.
@@ -406,17 +518,21 @@ func (r *Rand) Street() string {
 // If the country is not supported it will return an empty string.
 func (r *Rand) StreetForCountry(countrycode string) string {
 	switch countrycode {
-	case "US":
+	case "US", "CA":
 		return r.Street()
 	case "GB":
 		return fmt.Sprintf("%s %s", r.StringFrom(jsonData.StreetNameGB), r.StringFrom(jsonData.StreetTypesGB))
 	}
-	return ""
+	streets, ok := jsonData.Streets[countrycode]
+	if !ok {
+		return ""
+	}
+	return strings.NewReplacer("%n", r.StringFrom(streets.Names), "%t", r.StringFrom(streets.Types)).Replace(streets.Pattern)
 }
 
-// Address returns an american style address.
+// Address returns an american style address spanning two lines.
 func (r *Rand) Address() string {
-	return fmt.Sprintf("%d %s,\n%s, %s, %s", r.Number(100), r.Street(), r.City(), r.State(Small), r.PostalCode("US"))
+	return r.AddressForCountry("US").Format(MultiLine)
 }
 
 // Paragraph returns a random paragraph.
@@ -449,7 +565,7 @@ func uppercaseFirstLetter(word string) string {
 func (r *Rand) IpV4Address() string {
 	blocks := []string{}
 	for i := 0; i < 4; i++ {
-		number := r.Intn(255)
+		number := r.Intn(256)
 		blocks = append(blocks, strconv.Itoa(number))
 	}
 
@@ -460,26 +576,22 @@ func (r *Rand) IpV4Address() string {
 func (r *Rand) IpV6Address() string {
 	var ip net.IP
 	for i := 0; i < net.IPv6len; i++ {
-		number := uint8(r.Intn(255))
+		number := uint8(r.Intn(256))
 		ip = append(ip, number)
 	}
 	return ip.String()
 }
 
-// MacAddress returns an mac address string.
+// MacAddress returns a unicast mac address string starting with the OUI of a real vendor.
 func (r *Rand) MacAddress() string {
-	blocks := []string{}
-	for i := 0; i < 6; i++ {
-		number := fmt.Sprintf("%02x", r.Intn(255))
-		blocks = append(blocks, number)
-	}
-
-	return strings.Join(blocks, ":")
+	return r.MAC(MacOptions{}).String()
 }
 
-// UserAgentString returns a user agent used to browse the web.
+// UserAgentString returns the user agent of a current web browser. See UserAgent for more control.
 func (r *Rand) UserAgentString() string {
-	return r.StringFrom(jsonData.UserAgents)
+	browsers := []UserAgentClient{ChromeClient, ChromeClient, ChromeClient, SafariClient, SafariClient, EdgeClient, FirefoxClient}
+	ua, _ := r.UserAgent(UserAgentOptions{Client: browsers[r.Intn(len(browsers))]})
+	return ua
 }
 
 // Locale returns a random locale.
@@ -489,7 +601,12 @@ func (r *Rand) Locale() string {
 
 // PhoneNumber returns a random phone number.
 func (r *Rand) PhoneNumber() string {
-	str := r.StringFrom(jsonData.CountryCallingCodes) + " "
+	return r.phoneNumber(r.StringFrom(jsonData.CountryCallingCodes))
+}
+
+// phoneNumber returns a random phone number after the supplied calling code, e.g. "+49 30 1234567".
+func (r *Rand) phoneNumber(callingCode string) string {
+	str := callingCode + " "
 
 	str += r.Digits(r.Intn(3) + 1)
 
@@ -127,4 +127,7 @@ func Example() {
 
 	// Print full date
 	fmt.Println(r.FullDate())
+
+	// Print a random US social security number
+	fmt.Println(r.NationalID("US"))
 }
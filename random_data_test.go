@@ -45,6 +45,16 @@ func TestRand(t *testing.T) {
 		assert.Less(t, value, 1.0)
 	})
 
+	t.Run("should read random bytes", func(t *testing.T) {
+		b1, b2 := make([]byte, 32), make([]byte, 32)
+		n, err := FromSeed(1234).Read(b1)
+		assert.NoError(t, err)
+		assert.Equal(t, 32, n)
+		FromSeed(1234).Read(b2)
+		assert.Equal(t, b1, b2)
+		assert.NotEqual(t, make([]byte, 32), b1)
+	})
+
 	t.Run("should pick randomly a boolean value", func(t *testing.T) {
 		booleanVal := r.Boolean()
 		assert.True(t, booleanVal == true || booleanVal == false, "bool was wrong format")
@@ -100,6 +110,8 @@ func TestRand(t *testing.T) {
 		names = append(names, jsonData.MaleTitles...)
 		names = append(names, jsonData.FemaleTitles...)
 		assert.Contains(t, names, randomTitle, "randomName empty or not in male and female titles")
+
+		assert.Equal(t, "Mx", r.Title(NonBinary), "titleNonBinary empty or not Mx")
 	})
 
 	t.Run("should generate a first name", func(t *testing.T) {
@@ -110,6 +122,38 @@ func TestRand(t *testing.T) {
 		assert.Contains(t, jsonData.FirstNamesMale, firstNameMale, "firstNameMale empty or not in male names")
 		assert.Contains(t, jsonData.FirstNamesFemale, firstNameFemale, "firstNameFemale empty or not in female names")
 		assert.NotEmpty(t, randomName)
+
+		assert.Contains(t, jsonData.FirstNamesNeutral, r.FirstName(NonBinary), "firstNameNonBinary empty or not in neutral names")
+		assert.NotEmpty(t, r.FirstName(Gender(42)), "first name for unknown gender empty")
+	})
+
+	t.Run("should pick a gender according to a ratio", func(t *testing.T) {
+		assert.Equal(t, NonBinary, r.PickGender(GenderRatio{NonBinary: 1}))
+		assert.Equal(t, Female, r.PickGender(GenderRatio{Female: 3}))
+
+		counts := map[Gender]int{}
+		for i := 0; i < 10000; i++ {
+			counts[r.PickGender(GenderRatio{Male: 1, Female: 1, NonBinary: 2})]++
+		}
+		assert.InDelta(t, 2500, counts[Male], 250)
+		assert.InDelta(t, 2500, counts[Female], 250)
+		assert.InDelta(t, 5000, counts[NonBinary], 250)
+
+		counts = map[Gender]int{}
+		for i := 0; i < 10000; i++ {
+			counts[r.PickGender(GenderRatio{})]++
+		}
+		assert.InDelta(t, 4900, counts[Male], 250, "zero ratio should use DefaultGenderRatio")
+		assert.InDelta(t, 4900, counts[Female], 250, "zero ratio should use DefaultGenderRatio")
+		assert.InDelta(t, 200, counts[NonBinary], 100, "zero ratio should use DefaultGenderRatio")
+	})
+
+	t.Run("should print a gender", func(t *testing.T) {
+		assert.Equal(t, "male", Male.String())
+		assert.Equal(t, "female", Female.String())
+		assert.Equal(t, "nonbinary", NonBinary.String())
+		assert.Equal(t, "random", RandomGender.String())
+		assert.Equal(t, "Gender(42)", Gender(42).String())
 	})
 
 	t.Run("should generate a last name", func(t *testing.T) {
@@ -225,6 +269,15 @@ func TestIpV4Address(t *testing.T) {
 		assert.GreaterOrEqual(t, blockNumber, 0, "invalid generated IP address")
 		assert.LessOrEqual(t, blockNumber, 255, "invalid generated IP address")
 	}
+
+	octets := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		for _, block := range strings.Split(r.IpV4Address(), ".") {
+			octets[block] = true
+		}
+	}
+	assert.True(t, octets["255"], "octet 255 is never produced")
+	assert.Len(t, octets, 256)
 }
 
 func TestIpV6Address(t *testing.T) {
@@ -372,7 +425,7 @@ func CheckPhoneNumber(str string, t *testing.T, msgAndArgs ...interface{}) {
 
 func TestProvinceForCountry(t *testing.T) {
 	r := FromSeed(1234)
-	supportedCountries := []string{"US", "GB"}
+	supportedCountries := []string{"US", "GB", "DE", "FR", "JP"}
 	for _, c := range supportedCountries {
 		p := r.ProvinceForCountry(c)
 		assert.NotEmpty(t, p, "did not return a valid province for country %s", c)
@@ -381,6 +434,12 @@ func TestProvinceForCountry(t *testing.T) {
 			assert.Contains(t, jsonData.States, p, "did not return a known province for US")
 		case "GB":
 			assert.Contains(t, jsonData.ProvincesGB, p, "did not return a known province for GB")
+		default:
+			names := []string{}
+			for _, s := range Subdivisions(c) {
+				names = append(names, s.Name)
+			}
+			assert.Contains(t, names, p, "did not return a known province for %s", c)
 		}
 	}
 	assert.Empty(t, r.ProvinceForCountry("bogus"), "did not return empty province for unknown country")
@@ -388,7 +447,7 @@ func TestProvinceForCountry(t *testing.T) {
 
 func TestStreetForCountry(t *testing.T) {
 	r := FromSeed(1234)
-	supportedCountries := []string{"US", "GB"}
+	supportedCountries := []string{"US", "GB", "CA", "AU", "DE", "FR", "NL", "ES", "IT", "SE", "BR"}
 	for _, c := range supportedCountries {
 		p := r.StreetForCountry(c)
 		assert.NotEmpty(t, p, "did not return a valid street for country %s", c)
@@ -0,0 +1,115 @@
+package randomdata
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"hash/crc32"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var secretPatterns = map[SecretKind]string{
+	PrefixedAPIKey: `^[spr]k_(test|live)_[0-9A-Za-z]{30}$`,
+	GitHubToken:    `^gh[pousr]_[0-9A-Za-z]{36}$`,
+	AWSAccessKeyID: `^(AKIA|ASIA)[A-Z2-7]{16}$`,
+	HexToken:       `^[0-9a-f]{32}$`,
+	Base32Token:    `^[A-Z2-7]{26}$`,
+	Base64URLToken: `^[A-Za-z0-9_-]{22}$`,
+	JWTShapedToken: `^eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]{43}$`,
+}
+
+func TestSecret(t *testing.T) {
+	r := FromSeed(1234)
+	kinds := map[SecretKind]int{}
+	for i := 0; i < 1000; i++ {
+		secret := r.Secret(AnySecret)
+		kinds[secret.Kind]++
+		assert.Regexp(t, secretPatterns[secret.Kind], secret.Value, "invalid %v", secret.Kind)
+		assert.Equal(t, secret.Value, secret.String())
+	}
+	assert.Len(t, kinds, len(secretPatterns))
+	for kind, pattern := range secretPatterns {
+		secret := r.Secret(kind)
+		assert.Equal(t, kind, secret.Kind)
+		assert.Regexp(t, pattern, secret.Value)
+	}
+	assert.Equal(t, Secret{}, r.Secret(SecretKind(-1)))
+	assert.Equal(t, FromSeed(42).Secret(AnySecret), FromSeed(42).Secret(AnySecret))
+}
+
+func TestSecretChecksums(t *testing.T) {
+	r := FromSeed(1234)
+	for i := 0; i < 100; i++ {
+		key := r.APIKey("").Value
+		assert.Equal(t, crc32.ChecksumIEEE([]byte(key[:len(key)-6])), uint32(decodeBase(key[len(key)-6:], base62Alphabet).Int64()), key)
+
+		token := r.GitHubToken("").Value
+		assert.Equal(t, crc32.ChecksumIEEE([]byte(token[4:34])), uint32(decodeBase(token[34:], base62Alphabet).Int64()), token)
+	}
+	assert.True(t, strings.HasPrefix(r.APIKey("acme_").Value, "acme_"))
+	assert.Regexp(t, `^github_pat_[0-9A-Za-z]{36}$`, r.GitHubToken("github_pat_").Value)
+	assert.Equal(t, "000000", crc32Base62(""))
+	assert.Equal(t, big.NewInt(0xcbf43926), decodeBase(crc32Base62("123456789"), base62Alphabet))
+}
+
+func TestTokens(t *testing.T) {
+	r := FromSeed(1234)
+	for _, bits := range []int{1, 8, 64, 100, 128, 256} {
+		hexToken := r.HexToken(bits)
+		assert.Equal(t, HexToken, hexToken.Kind)
+		assert.Len(t, hexToken.Value, (bits+3)/4)
+		if bits%8 == 0 {
+			_, err := hex.DecodeString(hexToken.Value)
+			assert.NoError(t, err)
+		}
+
+		base32Token := r.Base32Token(bits)
+		assert.Equal(t, Base32Token, base32Token.Kind)
+		assert.Len(t, base32Token.Value, (bits+4)/5)
+		if bits%40 == 0 {
+			_, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(base32Token.Value)
+			assert.NoError(t, err)
+		}
+
+		base64Token := r.Base64URLToken(bits)
+		assert.Equal(t, Base64URLToken, base64Token.Kind)
+		assert.Len(t, base64Token.Value, (bits+5)/6)
+		if bits%24 == 0 {
+			_, err := base64.RawURLEncoding.DecodeString(base64Token.Value)
+			assert.NoError(t, err)
+		}
+	}
+	assert.Equal(t, Secret{}, r.HexToken(-1))
+	assert.Equal(t, Secret{}, r.Base32Token(-1))
+	assert.Equal(t, Secret{}, r.Base64URLToken(-1))
+}
+
+func TestJWTShaped(t *testing.T) {
+	r := FromSeed(1234)
+	for i := 0; i < 100; i++ {
+		parts := strings.Split(r.JWTShaped().Value, ".")
+		if !assert.Len(t, parts, 3) {
+			continue
+		}
+		var header map[string]string
+		var claims struct {
+			Subject  string `json:"sub"`
+			IssuedAt int64  `json:"iat"`
+			Expiry   int64  `json:"exp"`
+		}
+		for j, object := range []interface{}{&header, &claims} {
+			b, err := base64.RawURLEncoding.DecodeString(parts[j])
+			if assert.NoError(t, err) {
+				assert.NoError(t, json.Unmarshal(b, object))
+			}
+		}
+		assert.Equal(t, map[string]string{"alg": "HS256", "typ": "JWT"}, header)
+		assert.NotEmpty(t, claims.Subject)
+		assert.Equal(t, claims.IssuedAt+3600, claims.Expiry)
+	}
+}
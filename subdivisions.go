@@ -0,0 +1,66 @@
+package randomdata
+
+import (
+	"sort"
+	"strings"
+)
+
+// Subdivision is a country subdivision as defined by ISO 3166-2.
+type Subdivision struct {
+	Code string `json:"code"` // e.g. "DE-BY"
+	Name string `json:"name"` // e.g. "Bayern"
+	Type string `json:"type"` // e.g. "Land", "State", "Province"
+}
+
+// Subdivisions returns the ISO 3166-2 subdivisions of the supplied 2-letter country code.
+// Only the most detailed level is returned, e.g. the departments of France rather than its regions.
+// If the country is not supported it will return nil.
+func Subdivisions(countrycode string) []Subdivision {
+	entries := jsonData.Subdivisions[strings.ToUpper(countrycode)]
+	if len(entries) == 0 {
+		return nil
+	}
+	subdivisions := make([]Subdivision, len(entries))
+	for i, e := range entries {
+		subdivisions[i] = Subdivision{Code: e[0], Name: e[1], Type: e[2]}
+	}
+	return subdivisions
+}
+
+// SupportedProvinceCountries returns the sorted 2-letter codes of the countries having ISO 3166-2 subdivisions.
+// Territories without subdivisions of their own, such as "PR" or "GI", are not included.
+func SupportedProvinceCountries() []string {
+	countries := make([]string, 0, len(jsonData.Subdivisions))
+	for country := range jsonData.Subdivisions {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
+}
+
+// SubdivisionForCountry returns a random ISO 3166-2 subdivision of the supplied 2-letter country code.
+// If the country is not supported it will return an empty Subdivision.
+func (r *Rand) SubdivisionForCountry(countrycode string) Subdivision {
+	entries := jsonData.Subdivisions[strings.ToUpper(countrycode)]
+	if len(entries) == 0 {
+		return Subdivision{}
+	}
+	e := entries[r.Intn(len(entries))]
+	return Subdivision{Code: e[0], Name: e[1], Type: e[2]}
+}
+
+// ProvinceCodeForCountry returns a randomly selected ISO 3166-2 subdivision code, e.g. "DE-BY", for a supplied country.
+// If the country is not supported it will return an empty string.
+func (r *Rand) ProvinceCodeForCountry(countrycode string) string {
+	return r.SubdivisionForCountry(countrycode).Code
+}
+
+// subdivisionName returns the name of the subdivision with the supplied ISO 3166-2 code.
+func subdivisionName(code string) string {
+	for _, e := range jsonData.Subdivisions[strings.SplitN(code, "-", 2)[0]] {
+		if e[0] == code {
+			return e[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package randomdata
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubdivisions(t *testing.T) {
+	codeRegexp := regexp.MustCompile(`^[A-Z]{2}-[A-Z0-9]{1,3}$`)
+	for _, country := range SupportedProvinceCountries() {
+		subdivisions := Subdivisions(country)
+		assert.NotEmpty(t, subdivisions, "no subdivisions for country %q", country)
+		for _, s := range subdivisions {
+			assert.True(t, codeRegexp.MatchString(s.Code), "invalid subdivision code %q", s.Code)
+			assert.True(t, strings.HasPrefix(s.Code, country+"-"), "subdivision %q does not belong to %q", s.Code, country)
+			assert.NotEmpty(t, s.Name, "empty name for subdivision %q", s.Code)
+			assert.NotEmpty(t, s.Type, "empty type for subdivision %q", s.Code)
+		}
+	}
+
+	assert.Contains(t, Subdivisions("de"), Subdivision{Code: "DE-BY", Name: "Bayern", Type: "Land"})
+	assert.Len(t, Subdivisions("DE"), 16)
+	assert.Nil(t, Subdivisions("bogus"))
+}
+
+func TestSupportedProvinceCountries(t *testing.T) {
+	countries := SupportedProvinceCountries()
+	assert.True(t, sort.StringsAreSorted(countries))
+	assert.Greater(t, len(countries), 190)
+	for _, country := range countries {
+		assert.Contains(t, jsonData.CountriesTwoChars, country)
+	}
+	assert.Contains(t, countries, "FR")
+	assert.NotContains(t, countries, "PR")
+}
+
+func TestProvinceCodeForCountry(t *testing.T) {
+	r := FromSeed(1234)
+	for _, country := range SupportedProvinceCountries() {
+		code := r.ProvinceCodeForCountry(country)
+		assert.True(t, strings.HasPrefix(code, country+"-"), "invalid province code %q for country %q", code, country)
+
+		s := r.SubdivisionForCountry(country)
+		assert.Contains(t, Subdivisions(country), s)
+	}
+	assert.Empty(t, r.ProvinceCodeForCountry("bogus"), "did not return empty province code for unknown country")
+	assert.Equal(t, Subdivision{}, r.SubdivisionForCountry("bogus"))
+}
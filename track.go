@@ -0,0 +1,264 @@
+package randomdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// TravelMode decides how fast and how straight a Track moves.
+type TravelMode int
+
+const (
+	Walking TravelMode = iota
+	Cycling
+	Driving
+)
+
+// String returns the name of the travel mode.
+func (m TravelMode) String() string {
+	switch m {
+	case Walking:
+		return "walking"
+	case Cycling:
+		return "cycling"
+	case Driving:
+		return "driving"
+	}
+	return "unknown"
+}
+
+type travelProfile struct {
+	speed    float64 // mean speed in m/s
+	maxSpeed float64 // in m/s
+	sigma    float64 // variability of the speed, in m/s per √s
+	turn     float64 // variability of the heading in random walks, in degrees per √s
+}
+
+var travelProfiles = map[TravelMode]travelProfile{
+	Walking: {speed: 1.4, maxSpeed: 2.5, sigma: 0.1, turn: 8},
+	Cycling: {speed: 5, maxSpeed: 12, sigma: 0.4, turn: 5},
+	Driving: {speed: 13, maxSpeed: 36, sigma: 1.5, turn: 3},
+}
+
+// TrackOptions drives the generation of a Track.
+type TrackOptions struct {
+	// Mode is the way of travelling. The zero value is Walking.
+	Mode TravelMode
+	// StartTime is the time of the first point. The zero value means time.Now().
+	StartTime time.Time
+	// Interval is the time between two samples. The zero value means one second.
+	Interval time.Duration
+	// Points is the number of samples of a random walk. The zero value means 100.
+	// It is ignored when following waypoints: the track then ends at the last waypoint.
+	Points int
+	// Waypoints is the route to follow. When empty the track is a random walk.
+	Waypoints []LatLng
+	// Jitter is the largest random shift of each sampling time, capped to half the interval.
+	Jitter time.Duration
+	// DropoutRate is the probability for a sample to be missing, between 0 and 1.
+	DropoutRate float64
+	// Noise is the standard deviation, in meters, of the GPS error added to each recorded position.
+	Noise float64
+}
+
+// TrackPoint is a time-stamped position of a Track.
+type TrackPoint struct {
+	LatLng
+	Time    time.Time `json:"time"`
+	Speed   float64   `json:"speed"`   // in m/s
+	Heading float64   `json:"heading"` // in degrees clockwise from north
+}
+
+// Track is a sequence of time-stamped positions.
+type Track struct {
+	Mode   TravelMode   `json:"mode"`
+	Points []TrackPoint `json:"points"`
+}
+
+// speedReversion is the rate, in 1/s, at which the speed of a track reverts to the mean of its travel mode.
+const speedReversion = 0.1
+
+// maxTrackPoints bounds the number of samples of a track following waypoints.
+const maxTrackPoints = 1000000
+
+// Track returns a random movement trace starting at start.
+// The speed drifts around a value typical to the travel mode and the heading changes smoothly,
+// either at random or to head for the next waypoint.
+func (r *Rand) Track(start LatLng, opts TrackOptions) Track {
+	profile, ok := travelProfiles[opts.Mode]
+	if !ok {
+		profile = travelProfiles[Walking]
+	}
+	now := opts.StartTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	points := opts.Points
+	if points <= 0 {
+		points = 100
+	}
+	if len(opts.Waypoints) > 0 {
+		points = maxTrackPoints
+	}
+	jitter := opts.Jitter
+	if jitter > interval/2 {
+		jitter = interval / 2
+	}
+
+	track := Track{Mode: opts.Mode}
+	position, waypoint := start, 0
+	speed := math.Max(0, profile.speed+profile.sigma*r.NormFloat64())
+	heading := 360 * r.Float64()
+	if len(opts.Waypoints) > 0 {
+		heading = position.bearingTo(opts.Waypoints[0])
+	}
+	last := now
+	for i := 0; i < points; i++ {
+		at := now.Add(time.Duration(i) * interval)
+		if jitter > 0 {
+			at = at.Add(time.Duration(r.Float64()*float64(2*jitter)) - jitter)
+		}
+		dt := at.Sub(last).Seconds()
+		last = at
+
+		if dt > 0 {
+			// The speed follows an Ornstein–Uhlenbeck process reverting to the mean of the mode, updated exactly
+			// so that it stays stable for long intervals, and the heading drifts like a random walk.
+			decay := math.Exp(-speedReversion * dt)
+			speed = profile.speed + (speed-profile.speed)*decay +
+				profile.sigma*math.Sqrt((1-decay*decay)/(2*speedReversion))*r.NormFloat64()
+			speed = math.Min(math.Max(speed, 0), profile.maxSpeed)
+			step := speed * dt
+			if len(opts.Waypoints) > 0 {
+				for waypoint < len(opts.Waypoints) && position.DistanceTo(opts.Waypoints[waypoint]) <= step {
+					step -= position.DistanceTo(opts.Waypoints[waypoint])
+					position = opts.Waypoints[waypoint]
+					waypoint++
+				}
+				if waypoint < len(opts.Waypoints) {
+					heading = position.bearingTo(opts.Waypoints[waypoint]) + profile.turn*r.NormFloat64()
+				}
+			} else {
+				heading += profile.turn * math.Sqrt(dt) * r.NormFloat64()
+			}
+			heading = math.Mod(heading+360, 360)
+			if waypoint < len(opts.Waypoints) || len(opts.Waypoints) == 0 {
+				position = position.destination(radians(heading), step/earthRadius)
+			}
+		}
+
+		if opts.DropoutRate <= 0 || r.Float64() >= opts.DropoutRate {
+			recorded := position
+			if opts.Noise > 0 {
+				recorded = r.gpsNoise(position, opts.Noise)
+			}
+			track.Points = append(track.Points, TrackPoint{LatLng: recorded, Time: at, Speed: speed, Heading: heading})
+		}
+		if len(opts.Waypoints) > 0 && waypoint == len(opts.Waypoints) {
+			break
+		}
+	}
+	return track
+}
+
+// gpsNoise moves a position by a gaussian error of the supplied standard deviation, in meters, on each axis.
+func (r *Rand) gpsNoise(p LatLng, sigma float64) LatLng {
+	north, east := sigma*r.NormFloat64(), sigma*r.NormFloat64()
+	return LatLng{
+		Lat: math.Max(-90, math.Min(90, p.Lat+degrees(north/earthRadius))),
+		Lng: normalizeLng(p.Lng + degrees(east/(earthRadius*math.Cos(radians(p.Lat))))),
+	}
+}
+
+// bearingTo returns the initial bearing from p to q, in degrees clockwise from north.
+func (p LatLng) bearingTo(q LatLng) float64 {
+	lat1, lat2, dLng := radians(p.Lat), radians(q.Lat), radians(q.Lng-p.Lng)
+	y := math.Sin(dLng) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLng)
+	return math.Mod(degrees(math.Atan2(y, x))+360, 360)
+}
+
+// Length returns the distance travelled along the recorded points, in meters.
+func (t Track) Length() float64 {
+	length := 0.0
+	for i := 1; i < len(t.Points); i++ {
+		length += t.Points[i-1].DistanceTo(t.Points[i].LatLng)
+	}
+	return length
+}
+
+// GPX returns the track as a GPX 1.1 document.
+func (t Track) GPX() string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<gpx version="1.1" creator="go-randomdata" xmlns="http://www.topografix.com/GPX/1/1">` + "\n")
+	fmt.Fprintf(&b, "  <trk>\n    <name>%s</name>\n    <trkseg>\n", t.Mode)
+	for _, p := range t.Points {
+		fmt.Fprintf(&b, "      <trkpt lat=\"%.7f\" lon=\"%.7f\"><time>%s</time></trkpt>\n",
+			p.Lat, p.Lng, p.Time.UTC().Format(time.RFC3339Nano))
+	}
+	b.WriteString("    </trkseg>\n  </trk>\n</gpx>\n")
+	return b.String()
+}
+
+// GeoJSON returns the track as a GeoJSON Feature holding a LineString.
+// The times of the points are in the "coordTimes" property, as written by most GPX converters.
+func (t Track) GeoJSON() []byte {
+	coordinates := make([][2]float64, len(t.Points))
+	times := make([]string, len(t.Points))
+	for i, p := range t.Points {
+		coordinates[i] = [2]float64{p.Lng, p.Lat}
+		times[i] = p.Time.UTC().Format(time.RFC3339Nano)
+	}
+	feature, _ := json.Marshal(geoJSONFeature{
+		Type:       "Feature",
+		Geometry:   geoJSONGeometry{Type: "LineString", Coordinates: coordinates},
+		Properties: map[string]interface{}{"mode": t.Mode.String(), "coordTimes": times},
+	})
+	return feature
+}
+
+// NMEA returns the track as NMEA 0183 sentences, a GGA fix followed by an RMC record for every point.
+func (t Track) NMEA() string {
+	var b strings.Builder
+	for _, p := range t.Points {
+		utc := p.Time.UTC()
+		clock := fmt.Sprintf("%s.%02d", utc.Format("150405"), utc.Nanosecond()/1e7)
+		lat, lng := nmeaCoordinate(p.Lat, 2, "N", "S"), nmeaCoordinate(p.Lng, 3, "E", "W")
+		b.WriteString(nmeaSentence(fmt.Sprintf("GPGGA,%s,%s,%s,1,08,0.9,0.0,M,0.0,M,,", clock, lat, lng)))
+		b.WriteString(nmeaSentence(fmt.Sprintf("GPRMC,%s,A,%s,%s,%.1f,%.1f,%s,,,A",
+			clock, lat, lng, p.Speed*3600/1852, p.Heading, utc.Format("020106"))))
+	}
+	return b.String()
+}
+
+// nmeaCoordinate formats an angle as degrees and decimal minutes followed by its hemisphere,
+// e.g. "4807.0380,N".
+func nmeaCoordinate(angle float64, width int, positive, negative string) string {
+	hemisphere := positive
+	if angle < 0 {
+		hemisphere, angle = negative, -angle
+	}
+	deg := math.Floor(angle)
+	minutes := (angle - deg) * 60
+	if minutes >= 59.99995 {
+		deg, minutes = deg+1, 0
+	}
+	return fmt.Sprintf("%0*d%07.4f,%s", width, int(deg), minutes, hemisphere)
+}
+
+// nmeaSentence wraps the body of a sentence with its start delimiter and checksum.
+func nmeaSentence(body string) string {
+	checksum := byte(0)
+	for i := 0; i < len(body); i++ {
+		checksum ^= body[i]
+	}
+	return fmt.Sprintf("$%s*%02X\r\n", body, checksum)
+}
@@ -0,0 +1,158 @@
+package randomdata
+
+import (
+	"encoding/json"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrack(t *testing.T) {
+	r := FromSeed(1234)
+	start := LatLng{Lat: 52.3676, Lng: 4.9041}
+	startTime := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	speeds := map[TravelMode][2]float64{Walking: {0.5, 2.5}, Cycling: {2, 12}, Driving: {5, 36}}
+
+	for mode, bounds := range speeds {
+		track := r.Track(start, TrackOptions{Mode: mode, StartTime: startTime, Points: 600})
+		assert.Equal(t, mode, track.Mode)
+		assert.Len(t, track.Points, 600)
+		assert.Equal(t, startTime, track.Points[0].Time)
+		for i := 1; i < len(track.Points); i++ {
+			prev, p := track.Points[i-1], track.Points[i]
+			assert.Equal(t, time.Second, p.Time.Sub(prev.Time))
+			assert.InDelta(t, p.Speed, prev.DistanceTo(p.LatLng), 0.01, "%v moved further than its speed", mode)
+			turn := p.Heading - prev.Heading
+			if turn > 180 {
+				turn -= 360
+			} else if turn < -180 {
+				turn += 360
+			}
+			assert.InDelta(t, 0, turn, 45, "%v turned abruptly", mode)
+		}
+		average := track.Length() / 599
+		assert.True(t, average >= bounds[0] && average <= bounds[1], "unrealistic average speed %v for %v", average, mode)
+	}
+}
+
+func TestTrackLongInterval(t *testing.T) {
+	r := FromSeed(1234)
+	start := LatLng{Lat: 52.3676, Lng: 4.9041}
+	for _, interval := range []time.Duration{30 * time.Second, time.Minute, 10 * time.Minute} {
+		for mode, profile := range travelProfiles {
+			track := r.Track(start, TrackOptions{Mode: mode, Interval: interval, Points: 500})
+			// The stationary deviation of the speed is sigma/√(2θ), about 2.2 sigma.
+			sum := 0.0
+			for _, p := range track.Points {
+				assert.InDelta(t, profile.speed, p.Speed, 6*profile.sigma/math.Sqrt(2*speedReversion),
+					"%v speed out of range with a %v interval", mode, interval)
+				sum += p.Speed
+			}
+			assert.InDelta(t, profile.speed, sum/float64(len(track.Points)), profile.sigma,
+				"%v mean speed drifted with a %v interval", mode, interval)
+		}
+	}
+}
+
+func TestTrackWaypoints(t *testing.T) {
+	r := FromSeed(1234)
+	start := LatLng{Lat: 48.8584, Lng: 2.2945}
+	waypoints := []LatLng{{Lat: 48.8606, Lng: 2.3376}, {Lat: 48.8530, Lng: 2.3499}}
+	track := r.Track(start, TrackOptions{Mode: Cycling, Interval: 5 * time.Second, Points: 3})
+	assert.Len(t, track.Points, 3)
+
+	track = r.Track(start, TrackOptions{Mode: Cycling, Interval: 5 * time.Second, Waypoints: waypoints})
+	last := track.Points[len(track.Points)-1]
+	assert.Equal(t, waypoints[1], last.LatLng)
+	// The route is about 4.2 km long.
+	assert.InDelta(t, 4200, track.Length(), 400)
+	assert.Greater(t, len(track.Points), 50)
+}
+
+func TestTrackSampling(t *testing.T) {
+	r := FromSeed(1234)
+	start := LatLng{Lat: 40.7128, Lng: -74.0060}
+	startTime := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	track := r.Track(start, TrackOptions{Mode: Driving, StartTime: startTime, Points: 1000, Interval: 10 * time.Second, Jitter: time.Hour})
+	for i, p := range track.Points {
+		nominal := startTime.Add(time.Duration(i) * 10 * time.Second)
+		assert.InDelta(t, 0, p.Time.Sub(nominal).Seconds(), 5)
+	}
+
+	track = r.Track(start, TrackOptions{StartTime: startTime, Points: 1000, DropoutRate: 0.2})
+	assert.InDelta(t, 800, len(track.Points), 50)
+
+	clean := r.Track(start, TrackOptions{StartTime: startTime, Points: 200})
+	noisy := r.Track(start, TrackOptions{StartTime: startTime, Points: 200, Noise: 5})
+	assert.Len(t, noisy.Points, 200)
+	assert.Less(t, roughness(clean), 0.5)
+	assert.Greater(t, roughness(noisy), 3.0)
+}
+
+// roughness is the mean distance of the points of a track to the middle of their neighbours.
+func roughness(track Track) float64 {
+	total := 0.0
+	for i := 1; i < len(track.Points)-1; i++ {
+		prev, next := track.Points[i-1], track.Points[i+1]
+		middle := LatLng{Lat: (prev.Lat + next.Lat) / 2, Lng: (prev.Lng + next.Lng) / 2}
+		total += track.Points[i].DistanceTo(middle)
+	}
+	return total / float64(len(track.Points)-2)
+}
+
+func TestTrackFormats(t *testing.T) {
+	r := FromSeed(1234)
+	startTime := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	track := r.Track(LatLng{Lat: -33.8688, Lng: 151.2093}, TrackOptions{StartTime: startTime, Points: 10})
+
+	gpx := track.GPX()
+	assert.Contains(t, gpx, `<gpx version="1.1"`)
+	assert.Equal(t, 10, strings.Count(gpx, "<trkpt "))
+	assert.Contains(t, gpx, "<time>2024-05-01T08:00:00Z</time>")
+
+	var feature struct {
+		Geometry struct {
+			Type        string       `json:"type"`
+			Coordinates [][2]float64 `json:"coordinates"`
+		} `json:"geometry"`
+		Properties struct {
+			CoordTimes []string `json:"coordTimes"`
+		} `json:"properties"`
+	}
+	assert.NoError(t, json.Unmarshal(track.GeoJSON(), &feature))
+	assert.Equal(t, "LineString", feature.Geometry.Type)
+	assert.Len(t, feature.Geometry.Coordinates, 10)
+	assert.Equal(t, [2]float64{track.Points[3].Lng, track.Points[3].Lat}, feature.Geometry.Coordinates[3])
+	assert.Len(t, feature.Properties.CoordTimes, 10)
+
+	sentences := strings.Split(strings.TrimSuffix(track.NMEA(), "\r\n"), "\r\n")
+	assert.Len(t, sentences, 20)
+	format := regexp.MustCompile(`^\$(GPGGA|GPRMC),080\d{3}\.00,(?:A,)?33\d{2}\.\d{4},S,151\d{2}\.\d{4},E,.*\*([0-9A-F]{2})$`)
+	for _, sentence := range sentences {
+		match := format.FindStringSubmatch(sentence)
+		if !assert.NotNil(t, match, "invalid sentence %q", sentence) {
+			continue
+		}
+		checksum := byte(0)
+		body := sentence[1:strings.IndexByte(sentence, '*')]
+		for i := 0; i < len(body); i++ {
+			checksum ^= body[i]
+		}
+		assert.Equal(t, strconv.FormatUint(uint64(checksum)|0x100, 16)[1:], strings.ToLower(match[2]))
+	}
+}
+
+func TestNMEA(t *testing.T) {
+	// Example from the NMEA 0183 documentation.
+	assert.Equal(t, "$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47\r\n",
+		nmeaSentence("GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,"))
+	assert.Equal(t, "4807.0380,N", nmeaCoordinate(48.1173, 2, "N", "S"))
+	assert.Equal(t, "01131.0000,W", nmeaCoordinate(-11.516666666666667, 3, "E", "W"))
+	assert.Equal(t, "walking", Walking.String())
+}
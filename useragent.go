@@ -0,0 +1,427 @@
+package randomdata
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// UserAgentClient is the kind of software sending a User-Agent.
+type UserAgentClient int
+
+const (
+	AnyClient UserAgentClient = iota
+	ChromeClient
+	FirefoxClient
+	SafariClient
+	EdgeClient
+	MobileAppClient
+	BotClient
+	CLIClient
+)
+
+// String returns the name of the client kind.
+func (c UserAgentClient) String() string {
+	switch c {
+	case AnyClient:
+		return "any"
+	case ChromeClient:
+		return "Chrome"
+	case FirefoxClient:
+		return "Firefox"
+	case SafariClient:
+		return "Safari"
+	case EdgeClient:
+		return "Edge"
+	case MobileAppClient:
+		return "mobile app"
+	case BotClient:
+		return "bot"
+	case CLIClient:
+		return "command line"
+	}
+	return "unknown"
+}
+
+// DeviceType is the form factor of the device running a client.
+type DeviceType int
+
+const (
+	// AnyDevice lets the generator pick the device. In a UserAgentInfo it means the agent does not tell.
+	AnyDevice DeviceType = iota
+	DesktopDevice
+	MobileDevice
+	TabletDevice
+)
+
+// String returns the name of the device type.
+func (d DeviceType) String() string {
+	switch d {
+	case AnyDevice:
+		return "any"
+	case DesktopDevice:
+		return "desktop"
+	case MobileDevice:
+		return "mobile"
+	case TabletDevice:
+		return "tablet"
+	}
+	return "unknown"
+}
+
+// UserAgentOptions drives the generation of a User-Agent.
+type UserAgentOptions struct {
+	// Client is the kind of software. The zero value picks one following rough market shares.
+	Client UserAgentClient
+	// Device is the form factor of the device. The zero value picks one the client runs on.
+	// It is ignored for bots and command-line clients.
+	Device DeviceType
+}
+
+// UserAgentInfo is the structured content of a User-Agent, as an analytics parser would report it.
+type UserAgentInfo struct {
+	Client         UserAgentClient `json:"client"`
+	Browser        string          `json:"browser"` // browser, application, bot or tool name, e.g. "Chrome", "Googlebot" or "curl"
+	BrowserVersion string          `json:"browserVersion"`
+	Engine         string          `json:"engine"` // "Blink", "Gecko" or "WebKit", empty for other clients
+	EngineVersion  string          `json:"engineVersion"`
+	OS             string          `json:"os"` // e.g. "Windows", "macOS", "Linux", "ChromeOS", "Android", "iOS" or "iPadOS"
+	OSVersion      string          `json:"osVersion"`
+	Device         DeviceType      `json:"device"`
+	DeviceModel    string          `json:"deviceModel"` // e.g. "iPhone" or "Pixel 8", when the agent tells
+}
+
+// userAgentShares weights the clients picked when none is requested.
+var userAgentShares = []struct {
+	client UserAgentClient
+	weight int
+}{
+	{ChromeClient, 50}, {SafariClient, 20}, {EdgeClient, 8}, {FirefoxClient, 5},
+	{MobileAppClient, 7}, {BotClient, 6}, {CLIClient, 4},
+}
+
+var (
+	// Bots obtained from the documentation of each crawler.
+	userAgentBots = []struct{ name, version, format string }{
+		{"Googlebot", "2.1", "Mozilla/5.0 (compatible; Googlebot/%s; +http://www.google.com/bot.html)"},
+		{"bingbot", "2.0", "Mozilla/5.0 (compatible; bingbot/%s; +http://www.bing.com/bingbot.htm)"},
+		{"YandexBot", "3.0", "Mozilla/5.0 (compatible; YandexBot/%s; +http://yandex.com/bots)"},
+		{"DuckDuckBot", "1.1", "DuckDuckBot/%s; (+http://duckduckgo.com/duckduckbot.html)"},
+		{"Baiduspider", "2.0", "Mozilla/5.0 (compatible; Baiduspider/%s; +http://www.baidu.com/search/spider.html)"},
+		{"AhrefsBot", "7.0", "Mozilla/5.0 (compatible; AhrefsBot/%s; +http://ahrefs.com/robot/)"},
+		{"GPTBot", "1.2", "Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko); compatible; GPTBot/%s; +https://openai.com/gptbot"},
+		{"facebookexternalhit", "1.1", "facebookexternalhit/%s (+http://www.facebook.com/externalhit_uatext.php)"},
+		{"Twitterbot", "1.0", "Twitterbot/%s"},
+	}
+	userAgentTools = []struct {
+		name     string
+		versions []string
+	}{
+		{"curl", []string{"7.81.0", "7.88.1", "8.5.0", "8.7.1", "8.9.1", "8.11.1", "8.14.1"}},
+		{"Wget", []string{"1.21.2", "1.21.4", "1.24.5"}},
+		{"python-requests", []string{"2.28.2", "2.31.0", "2.32.3"}},
+		{"Go-http-client", []string{"1.1", "2.0"}},
+		{"PostmanRuntime", []string{"7.37.3", "7.39.0", "7.43.0"}},
+		{"HTTPie", []string{"3.2.2", "3.2.4"}},
+		{"axios", []string{"1.6.8", "1.7.2", "1.7.9"}},
+	}
+	userAgentApps = []string{
+		"BlueFox", "Shopper", "Fitly", "NewsHub", "PhotoBox", "Tasker", "Streamly", "WeatherNow", "Budgeteer", "ChatterBox",
+	}
+	// Android device models, with the build identifiers of their firmware.
+	userAgentAndroidPhones  = []string{"Pixel 7", "Pixel 8", "Pixel 9 Pro", "SM-S911B", "SM-S921B", "SM-A546B", "2201116SG", "CPH2581"}
+	userAgentAndroidTablets = []string{"SM-X710", "SM-X910", "Pixel Tablet", "Lenovo TB-X606F"}
+	userAgentAndroidBuilds  = []string{"UQ1A.240205.004", "AP2A.240805.005", "TP1A.220624.014", "BP1A.250505.005"}
+)
+
+// UserAgent returns a random User-Agent following the supplied options, with the information a parser should find in it.
+// Versions follow the releases of the last years and the reduced User-Agent strings sent by current browsers,
+// e.g. Chrome freezes its minor version numbers and the version of Android.
+func (r *Rand) UserAgent(opts UserAgentOptions) (string, UserAgentInfo) {
+	client := opts.Client
+	if client == AnyClient {
+		total := 0
+		for _, share := range userAgentShares {
+			total += share.weight
+		}
+		pick := r.Intn(total)
+		for _, share := range userAgentShares {
+			if pick -= share.weight; pick < 0 {
+				client = share.client
+				break
+			}
+		}
+	}
+	info := UserAgentInfo{Client: client, Device: opts.Device}
+	switch client {
+	case BotClient:
+		bot := userAgentBots[r.Intn(len(userAgentBots))]
+		info.Browser, info.BrowserVersion, info.Device = bot.name, bot.version, AnyDevice
+		return fmt.Sprintf(bot.format, bot.version), info
+	case CLIClient:
+		tool := userAgentTools[r.Intn(len(userAgentTools))]
+		info.Browser, info.BrowserVersion, info.Device = tool.name, r.StringFrom(tool.versions), AnyDevice
+		return info.Browser + "/" + info.BrowserVersion, info
+	}
+
+	if info.Device == AnyDevice {
+		info.Device = []DeviceType{DesktopDevice, DesktopDevice, MobileDevice, MobileDevice, TabletDevice}[r.Intn(5)]
+	}
+	if client == MobileAppClient && info.Device == DesktopDevice {
+		info.Device = MobileDevice
+	}
+	info.OS = r.userAgentOS(client, info.Device)
+	switch info.OS {
+	case "Windows":
+		info.OSVersion = "10"
+	case "macOS":
+		info.OSVersion = "10.15.7"
+	case "ChromeOS":
+		info.OSVersion = fmt.Sprintf("%d.0.0", r.Number(15000, 16300))
+	case "Android":
+		info.OSVersion = strconv.Itoa(r.Number(12, 16))
+	case "iOS", "iPadOS":
+		info.OSVersion = fmt.Sprintf("%d.%d", r.Number(16, 19), r.Intn(7))
+		info.DeviceModel = map[DeviceType]string{MobileDevice: "iPhone", TabletDevice: "iPad"}[info.Device]
+	}
+	chrome := strconv.Itoa(r.Number(124, 142))
+	switch client {
+	case ChromeClient, EdgeClient:
+		info.Browser = client.String()
+		if info.OS == "iOS" || info.OS == "iPadOS" {
+			info.Engine, info.EngineVersion = "WebKit", "605.1.15"
+			info.BrowserVersion = fmt.Sprintf("%s.0.%d.%d", chrome, r.Number(6300, 7500), r.Number(40, 200))
+			token := map[UserAgentClient]string{ChromeClient: "CriOS", EdgeClient: "EdgiOS"}[client]
+			return fmt.Sprintf("Mozilla/5.0 (%s) AppleWebKit/605.1.15 (KHTML, like Gecko) %s/%s Mobile/15E148 Safari/604.1",
+				iosPlatform(info), token, info.BrowserVersion), info
+		}
+		info.Engine, info.BrowserVersion = "Blink", chrome+".0.0.0"
+		info.EngineVersion = info.BrowserVersion
+		platform, mobile := "", ""
+		switch info.OS {
+		case "Windows":
+			platform = "Windows NT 10.0; Win64; x64"
+		case "macOS":
+			platform = "Macintosh; Intel Mac OS X 10_15_7"
+		case "Linux":
+			platform = "X11; Linux x86_64"
+		case "ChromeOS":
+			platform = "X11; CrOS x86_64 " + info.OSVersion
+		case "Android":
+			// Chrome reduces the platform of Android to a fixed version and model.
+			platform, info.OSVersion = "Linux; Android 10; K", "10"
+			if info.Device == MobileDevice {
+				mobile = "Mobile "
+			}
+		}
+		ua := fmt.Sprintf("Mozilla/5.0 (%s) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%s %sSafari/537.36",
+			platform, info.BrowserVersion, mobile)
+		if client == EdgeClient {
+			token := "Edg"
+			if info.OS == "Android" {
+				token = "EdgA"
+			}
+			ua += " " + token + "/" + info.BrowserVersion
+		}
+		return ua, info
+	case FirefoxClient:
+		info.Browser = "Firefox"
+		version := strconv.Itoa(r.Number(125, 144))
+		if r.Intn(10) == 0 {
+			// Extended support releases.
+			version = r.StringFrom([]string{"115", "128", "140"})
+		}
+		if info.OS == "iOS" || info.OS == "iPadOS" {
+			info.Engine, info.EngineVersion, info.BrowserVersion = "WebKit", "605.1.15", version+"."+strconv.Itoa(r.Intn(3))
+			return fmt.Sprintf("Mozilla/5.0 (%s) AppleWebKit/605.1.15 (KHTML, like Gecko) FxiOS/%s Mobile/15E148 Safari/605.1.15",
+				iosPlatform(info), info.BrowserVersion), info
+		}
+		info.Engine, info.EngineVersion, info.BrowserVersion = "Gecko", version+".0", version+".0"
+		platform, gecko := "", "20100101"
+		switch info.OS {
+		case "Windows":
+			platform = "Windows NT 10.0; Win64; x64"
+		case "macOS":
+			platform, info.OSVersion = "Macintosh; Intel Mac OS X 10.15", "10.15"
+		case "Linux":
+			platform = "X11; Linux x86_64"
+			if r.Intn(3) == 0 {
+				platform = "X11; Ubuntu; Linux x86_64"
+			}
+		case "Android":
+			platform, gecko = "Android "+info.OSVersion+"; "+map[DeviceType]string{MobileDevice: "Mobile", TabletDevice: "Tablet"}[info.Device], info.EngineVersion
+		}
+		return fmt.Sprintf("Mozilla/5.0 (%s; rv:%s) Gecko/%s Firefox/%s", platform, info.EngineVersion, gecko, info.BrowserVersion), info
+	case SafariClient:
+		info.Browser, info.Engine, info.EngineVersion = "Safari", "WebKit", "605.1.15"
+		if info.OS == "macOS" {
+			info.BrowserVersion = fmt.Sprintf("%d.%d", r.Number(17, 19), r.Intn(7))
+			return fmt.Sprintf("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/%s Safari/605.1.15",
+				info.BrowserVersion), info
+		}
+		info.BrowserVersion = info.OSVersion
+		return fmt.Sprintf("Mozilla/5.0 (%s) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/%s Mobile/15E148 Safari/604.1",
+			iosPlatform(info), info.BrowserVersion), info
+	}
+
+	// Mobile applications use the default agent of their HTTP library, behind their own name.
+	info.Client = MobileAppClient
+	info.Browser = r.StringFrom(userAgentApps)
+	info.BrowserVersion = fmt.Sprintf("%d.%d.%d", r.Number(1, 12), r.Intn(30), r.Intn(10))
+	if info.OS == "Android" {
+		models := userAgentAndroidPhones
+		if info.Device == TabletDevice {
+			models = userAgentAndroidTablets
+		}
+		info.DeviceModel = r.StringFrom(models)
+		return fmt.Sprintf("%s/%s Dalvik/2.1.0 (Linux; U; Android %s; %s Build/%s)",
+			info.Browser, info.BrowserVersion, info.OSVersion, info.DeviceModel, r.StringFrom(userAgentAndroidBuilds)), info
+	}
+	return fmt.Sprintf("%s/%s (%s; %s %s; Scale/%d.00)", info.Browser, info.BrowserVersion, info.DeviceModel, info.OS,
+		info.OSVersion, r.Number(2, 4)), info
+}
+
+// userAgentOS picks an operating system running the client on the device.
+func (r *Rand) userAgentOS(client UserAgentClient, device DeviceType) string {
+	var systems []string
+	switch device {
+	case DesktopDevice:
+		switch client {
+		case ChromeClient:
+			systems = []string{"Windows", "Windows", "Windows", "macOS", "Linux", "ChromeOS"}
+		case FirefoxClient:
+			systems = []string{"Windows", "Windows", "macOS", "Linux"}
+		case SafariClient:
+			systems = []string{"macOS"}
+		case EdgeClient:
+			systems = []string{"Windows", "Windows", "Windows", "macOS"}
+		default:
+			systems = []string{"Windows"}
+		}
+	case TabletDevice:
+		systems = []string{"Android", "iPadOS"}
+		if client == SafariClient {
+			systems = []string{"iPadOS"}
+		}
+	default:
+		systems = []string{"Android", "iOS"}
+		if client == SafariClient {
+			systems = []string{"iOS"}
+		}
+	}
+	return r.StringFrom(systems)
+}
+
+// iosPlatform returns the platform of the agents of iOS and iPadOS browsers.
+func iosPlatform(info UserAgentInfo) string {
+	version := strings.Replace(info.OSVersion, ".", "_", -1)
+	if info.Device == TabletDevice {
+		return "iPad; CPU OS " + version + " like Mac OS X"
+	}
+	return "iPhone; CPU iPhone OS " + version + " like Mac OS X"
+}
+
+var (
+	uaBotPattern      *regexp.Regexp
+	uaToolPattern     *regexp.Regexp
+	uaDalvikPattern   = regexp.MustCompile(`^([^/\s]+)/(\S+) Dalvik/[\d.]+ \(Linux; U; Android ([\d.]+); (.+) Build/`)
+	uaAppPattern      = regexp.MustCompile(`^([^/\s]+)/(\S+) \((iPhone|iPad); (iOS|iPadOS) ([\d.]+); Scale/`)
+	uaIOSPattern      = regexp.MustCompile(`\((iPhone|iPad); CPU (?:iPhone )?OS ([\d_]+) like Mac OS X\)`)
+	uaAndroidPattern  = regexp.MustCompile(`\((?:Linux; )?Android ([\d.]+);`)
+	uaChromeOSPattern = regexp.MustCompile(`CrOS \S+ ([\d.]+)\)`)
+	uaMacPattern      = regexp.MustCompile(`Mac OS X ([\d_.]+)[;)]`)
+	uaTokenPattern    = regexp.MustCompile(`(Edg|EdgA|EdgiOS|CriOS|FxiOS|Firefox|Chrome|Version|rv|AppleWebKit)[/:]([\d.]+)`)
+)
+
+func init() {
+	bots := make([]string, len(userAgentBots))
+	for i, bot := range userAgentBots {
+		bots[i] = regexp.QuoteMeta(bot.name)
+	}
+	uaBotPattern = regexp.MustCompile(`(` + strings.Join(bots, "|") + `)/([\d.]+)`)
+	tools := make([]string, len(userAgentTools))
+	for i, tool := range userAgentTools {
+		tools[i] = regexp.QuoteMeta(tool.name)
+	}
+	uaToolPattern = regexp.MustCompile(`^(` + strings.Join(tools, "|") + `)/([\d.]+)$`)
+}
+
+// ParseUserAgent extracts the information of a User-Agent sent by the major browsers, well-known bots,
+// command-line clients and mobile applications using the default agent of their HTTP library.
+// It reverses UserAgent; the fields it can't find are left empty.
+func ParseUserAgent(ua string) UserAgentInfo {
+	var info UserAgentInfo
+	if m := uaBotPattern.FindStringSubmatch(ua); m != nil {
+		return UserAgentInfo{Client: BotClient, Browser: m[1], BrowserVersion: m[2]}
+	}
+	if m := uaToolPattern.FindStringSubmatch(ua); m != nil {
+		return UserAgentInfo{Client: CLIClient, Browser: m[1], BrowserVersion: m[2]}
+	}
+	if m := uaDalvikPattern.FindStringSubmatch(ua); m != nil {
+		info = UserAgentInfo{Client: MobileAppClient, Browser: m[1], BrowserVersion: m[2], OS: "Android", OSVersion: m[3], DeviceModel: m[4], Device: MobileDevice}
+		for _, tablet := range userAgentAndroidTablets {
+			if tablet == m[4] {
+				info.Device = TabletDevice
+			}
+		}
+		return info
+	}
+	if m := uaAppPattern.FindStringSubmatch(ua); m != nil {
+		info = UserAgentInfo{Client: MobileAppClient, Browser: m[1], BrowserVersion: m[2], OS: m[4], OSVersion: m[5], DeviceModel: m[3], Device: MobileDevice}
+		if m[3] == "iPad" {
+			info.Device = TabletDevice
+		}
+		return info
+	}
+
+	// Operating system and device.
+	if m := uaIOSPattern.FindStringSubmatch(ua); m != nil {
+		info.OS, info.OSVersion, info.DeviceModel, info.Device = "iOS", strings.Replace(m[2], "_", ".", -1), m[1], MobileDevice
+		if m[1] == "iPad" {
+			info.OS, info.Device = "iPadOS", TabletDevice
+		}
+	} else if m := uaAndroidPattern.FindStringSubmatch(ua); m != nil {
+		info.OS, info.OSVersion, info.Device = "Android", m[1], TabletDevice
+		if strings.Contains(ua, "Mobile") {
+			info.Device = MobileDevice
+		}
+	} else if strings.Contains(ua, "Windows NT 10.0") {
+		info.OS, info.OSVersion, info.Device = "Windows", "10", DesktopDevice
+	} else if m := uaChromeOSPattern.FindStringSubmatch(ua); m != nil {
+		info.OS, info.OSVersion, info.Device = "ChromeOS", m[1], DesktopDevice
+	} else if m := uaMacPattern.FindStringSubmatch(ua); m != nil {
+		info.OS, info.OSVersion, info.Device = "macOS", strings.Replace(m[1], "_", ".", -1), DesktopDevice
+	} else if strings.Contains(ua, "Linux") {
+		info.OS, info.Device = "Linux", DesktopDevice
+	}
+
+	// Browser and engine, the most specific tokens first as browsers mention the ones they are compatible with.
+	tokens := map[string]string{}
+	for _, m := range uaTokenPattern.FindAllStringSubmatch(ua, -1) {
+		tokens[m[1]] = m[2]
+	}
+	if info.OS == "iOS" || info.OS == "iPadOS" || info.OS == "macOS" && tokens["Version"] != "" {
+		info.Engine, info.EngineVersion = "WebKit", tokens["AppleWebKit"]
+	}
+	switch {
+	case tokens["Edg"] != "" || tokens["EdgA"] != "" || tokens["EdgiOS"] != "":
+		info.Client, info.Browser = EdgeClient, "Edge"
+		info.BrowserVersion = tokens["Edg"] + tokens["EdgA"] + tokens["EdgiOS"]
+	case tokens["CriOS"] != "":
+		info.Client, info.Browser, info.BrowserVersion = ChromeClient, "Chrome", tokens["CriOS"]
+	case tokens["FxiOS"] != "":
+		info.Client, info.Browser, info.BrowserVersion = FirefoxClient, "Firefox", tokens["FxiOS"]
+	case tokens["Firefox"] != "":
+		info.Client, info.Browser, info.BrowserVersion = FirefoxClient, "Firefox", tokens["Firefox"]
+		info.Engine, info.EngineVersion = "Gecko", tokens["rv"]
+	case tokens["Chrome"] != "":
+		info.Client, info.Browser, info.BrowserVersion = ChromeClient, "Chrome", tokens["Chrome"]
+	case tokens["Version"] != "" && strings.Contains(ua, "Safari/"):
+		info.Client, info.Browser, info.BrowserVersion = SafariClient, "Safari", tokens["Version"]
+	}
+	if tokens["Chrome"] != "" && info.Engine == "" {
+		info.Engine, info.EngineVersion = "Blink", tokens["Chrome"]
+	}
+	return info
+}
@@ -0,0 +1,79 @@
+package randomdata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserAgent(t *testing.T) {
+	r := FromSeed(1234)
+	clients := map[UserAgentClient]int{}
+	for i := 0; i < 5000; i++ {
+		ua, info := r.UserAgent(UserAgentOptions{})
+		assert.NotEqual(t, AnyClient, info.Client, ua)
+		assert.Equal(t, info, ParseUserAgent(ua), ua)
+		clients[info.Client]++
+	}
+	for _, client := range []UserAgentClient{ChromeClient, FirefoxClient, SafariClient, EdgeClient, MobileAppClient, BotClient, CLIClient} {
+		assert.NotZero(t, clients[client], "no %v agent", client)
+	}
+	assert.Greater(t, clients[ChromeClient], clients[FirefoxClient])
+}
+
+func TestUserAgentOptions(t *testing.T) {
+	r := FromSeed(1234)
+	for _, client := range []UserAgentClient{ChromeClient, FirefoxClient, SafariClient, EdgeClient, MobileAppClient} {
+		for _, device := range []DeviceType{DesktopDevice, MobileDevice, TabletDevice} {
+			for i := 0; i < 50; i++ {
+				ua, info := r.UserAgent(UserAgentOptions{Client: client, Device: device})
+				assert.Equal(t, client, info.Client, ua)
+				if client == MobileAppClient && device == DesktopDevice {
+					assert.Equal(t, MobileDevice, info.Device, ua)
+				} else {
+					assert.Equal(t, device, info.Device, ua)
+				}
+				assert.NotEmpty(t, info.OS, ua)
+				assert.NotEmpty(t, info.BrowserVersion, ua)
+				if client != MobileAppClient {
+					assert.Contains(t, []string{"Blink", "Gecko", "WebKit"}, info.Engine, ua)
+					if info.OS == "iOS" || info.OS == "iPadOS" {
+						assert.Equal(t, "WebKit", info.Engine, "every iOS browser uses WebKit: %s", ua)
+					}
+				}
+				assert.Equal(t, info, ParseUserAgent(ua), ua)
+			}
+		}
+	}
+	for _, client := range []UserAgentClient{BotClient, CLIClient} {
+		ua, info := r.UserAgent(UserAgentOptions{Client: client, Device: MobileDevice})
+		assert.Equal(t, AnyDevice, info.Device, ua)
+		assert.Empty(t, info.OS, ua)
+	}
+}
+
+func TestParseUserAgent(t *testing.T) {
+	for ua, info := range map[string]UserAgentInfo{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36": {
+			Client: ChromeClient, Browser: "Chrome", BrowserVersion: "126.0.0.0", Engine: "Blink", EngineVersion: "126.0.0.0",
+			OS: "Windows", OSVersion: "10", Device: DesktopDevice,
+		},
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1": {
+			Client: SafariClient, Browser: "Safari", BrowserVersion: "17.5", Engine: "WebKit", EngineVersion: "605.1.15",
+			OS: "iOS", OSVersion: "17.5", Device: MobileDevice, DeviceModel: "iPhone",
+		},
+		"Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0": {
+			Client: FirefoxClient, Browser: "Firefox", BrowserVersion: "128.0", Engine: "Gecko", EngineVersion: "128.0",
+			OS: "Linux", Device: DesktopDevice,
+		},
+		"Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Mobile Safari/537.36 EdgA/126.0.0.0": {
+			Client: EdgeClient, Browser: "Edge", BrowserVersion: "126.0.0.0", Engine: "Blink", EngineVersion: "126.0.0.0",
+			OS: "Android", OSVersion: "10", Device: MobileDevice,
+		},
+		"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)": {Client: BotClient, Browser: "Googlebot", BrowserVersion: "2.1"},
+		"curl/8.7.1":              {Client: CLIClient, Browser: "curl", BrowserVersion: "8.7.1"},
+		"Unknown/1.0 (something)": {},
+	} {
+		assert.Equal(t, info, ParseUserAgent(ua), ua)
+	}
+}